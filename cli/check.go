@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"are/core"
+)
+
+// query holds the flags "are check" and "are explain" share: the artifact to
+// decide against and the request to decide.
+type query struct {
+	artifact     string
+	keys         string
+	subject      string
+	action       string
+	resource     string
+	jurisdiction string
+	operation    string
+	at           string
+	json         bool
+}
+
+func newQueryFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *query) {
+	fs := newFlagSet(name, "", stderr)
+	q := &query{}
+	fs.StringVar(&q.artifact, "artifact", "", "compiled artifact file to decide against (required)")
+	fs.StringVar(&q.keys, "keys", "", "comma-separated Ed25519 public key files; if set, the artifact must be signed by one of them")
+	fs.StringVar(&q.subject, "subject", "", "subject of the request (required)")
+	fs.StringVar(&q.action, "action", "", "action of the request (required)")
+	fs.StringVar(&q.resource, "resource", "", "resource of the request (required)")
+	fs.StringVar(&q.jurisdiction, "jurisdiction", "", "jurisdiction the request is made in; setting any of -jurisdiction, -operation, or -time enforces claim scope")
+	fs.StringVar(&q.operation, "operation", "", "operation the request performs")
+	fs.StringVar(&q.at, "time", "", "RFC 3339 time to evaluate the request at (default now)")
+	fs.BoolVar(&q.json, "json", false, "print the result as JSON")
+	return fs, q
+}
+
+// request builds the authorization request from the flags.
+func (q *query) request() (core.AuthorizationRequest, error) {
+	if q.artifact == "" {
+		return core.AuthorizationRequest{}, fmt.Errorf("-artifact is required")
+	}
+	if q.subject == "" || q.action == "" || q.resource == "" {
+		return core.AuthorizationRequest{}, fmt.Errorf("-subject, -action, and -resource are required")
+	}
+	req := core.AuthorizationRequest{Subject: q.subject, Action: q.action, Resource: q.resource}
+	if q.jurisdiction == "" && q.operation == "" && q.at == "" {
+		return req, nil
+	}
+	req.Context = &core.RequestContext{Jurisdiction: q.jurisdiction, Operation: q.operation}
+	if q.at != "" {
+		at, err := time.Parse(time.RFC3339, q.at)
+		if err != nil {
+			return core.AuthorizationRequest{}, fmt.Errorf("-time: %w", err)
+		}
+		req.Context.Time = at
+	}
+	return req, nil
+}
+
+// parseQuery parses the flags of a query command. It returns the exit status to
+// stop with, or -1 to go on.
+func parseQuery(name string, args []string, stderr io.Writer) (*core.RuntimeInterface, core.AuthorizationRequest, *query, int) {
+	fs, q := newQueryFlagSet(name, stderr)
+	if status := parseFlags(fs, args); status >= 0 {
+		return nil, core.AuthorizationRequest{}, q, status
+	}
+	req, err := q.request()
+	if err != nil {
+		fmt.Fprintf(stderr, "are %s: %v\n", name, err)
+		fs.Usage()
+		return nil, req, q, ExitUsage
+	}
+	runtime, err := loadRuntime(q.artifact, q.keys)
+	if err != nil {
+		fmt.Fprintf(stderr, "are %s: %v\n", name, err)
+		return nil, req, q, ExitFailure
+	}
+	return runtime, req, q, -1
+}
+
+// runCheck implements "are check": it decides one request and prints the
+// decision. It exits with ExitOK if the request is allowed and ExitDenied if not,
+// so it can be used in scripts.
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	runtime, req, q, status := parseQuery("check", args, stderr)
+	if status >= 0 {
+		return status
+	}
+	result := runtime.Authorize(req)
+	if q.json {
+		if err := writeJSON(stdout, result); err != nil {
+			fmt.Fprintf(stderr, "are check: %v\n", err)
+			return ExitFailure
+		}
+	} else {
+		fmt.Fprintf(stdout, "%s: %s\n", decisionWord(result.Allowed), result.Reason)
+		if result.Proof.Claim != nil {
+			fmt.Fprintf(stdout, "authority: %s\n", result.AuthorityID)
+		}
+	}
+	if !result.Allowed {
+		return ExitDenied
+	}
+	return ExitOK
+}
+
+func decisionWord(allowed bool) string {
+	if allowed {
+		return "allowed"
+	}
+	return "denied"
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,202 @@
+// Package cli implements the are command.
+//
+//	are compile [flags] source...   compile sources into an artifact and proof
+//	are check [flags]               decide a request against an artifact
+//	are explain [flags]             decide a request and print the reasoning behind it
+//	are test [flags] suite...       run policy test suites (see package policytest)
+//	are serve [flags]               serve an artifact over HTTP and gRPC
+//
+// Sources are policy files (.are), or YAML or JSON source files (.yaml, .yml,
+// .json) as read by the loader package. Run "are <command> -h" for flags.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"are/core"
+	"are/loader"
+	"are/policy"
+)
+
+// Exit statuses returned by Run.
+const (
+	ExitOK      = 0 // success
+	ExitFailure = 1 // the command ran and failed, e.g. compilation failed
+	ExitUsage   = 2 // the command line was invalid
+	ExitDenied  = 3 // "are check" decided the request was not allowed
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+func commands() []command {
+	return []command{
+		{"compile", "compile sources into an artifact and proof", runCompile},
+		{"check", "decide a request against an artifact", runCheck},
+		{"explain", "decide a request and print the reasoning behind it", runExplain},
+		{"test", "run policy test suites", runTest},
+		{"serve", "serve an artifact over HTTP and gRPC", runServe},
+	}
+}
+
+// Run runs the are command with args, which exclude the program name, and
+// returns the process exit status.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return ExitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return ExitOK
+	}
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "are: unknown command %q\n", args[0])
+	usage(stderr)
+	return ExitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Authority Realization Engine")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  are <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "are <command> -h" for the flags of a command.`)
+}
+
+// newFlagSet creates the flag set of a command, reporting errors to stderr.
+func newFlagSet(name, arguments string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: are %s [flags] %s\n\nFlags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses a command's flags and returns the exit status to stop with,
+// or -1 to go on.
+func parseFlags(fs *flag.FlagSet, args []string) int {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	return -1
+}
+
+// loadSources reads authority sources from policy, YAML, and JSON files, in order.
+func loadSources(paths []string) ([]core.AuthoritySource, error) {
+	sources := []core.AuthoritySource{}
+	for _, path := range paths {
+		var loaded []core.AuthoritySource
+		var err error
+		if strings.ToLower(filepath.Ext(path)) == ".are" {
+			var file *policy.File
+			if file, err = policy.ParseFile(path); err == nil {
+				loaded, err = file.AuthoritySources()
+			}
+		} else {
+			loaded, err = loader.LoadFile(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, loaded...)
+	}
+	return sources, nil
+}
+
+// loadRuntime reads the artifact file at path and creates a runtime enforcing it.
+// If keys, a comma-separated list of Ed25519 public key files, is not empty, the
+// artifact must carry a signature by one of them.
+func loadRuntime(path, keys string) (*core.RuntimeInterface, error) {
+	artifact, sig, err := core.ReadArtifactFile(path)
+	if err != nil {
+		return nil, err
+	}
+	opts := []core.RuntimeOption{}
+	if keys != "" {
+		ring, err := core.LoadKeyRing(strings.Split(keys, ",")...)
+		if err != nil {
+			return nil, err
+		}
+		if sig == nil {
+			return nil, fmt.Errorf("%s: %w: artifact is not signed", path, core.ErrInvalidSignature)
+		}
+		opts = append(opts, core.WithSignature(*sig, ring))
+	}
+	runtime, err := core.NewValidatedRuntimeInterface(artifact, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return runtime, nil
+}
+
+// reloadRuntime reads the artifact file at path again and puts it in force in
+// runtime, which applies the checks it was created with: if it was loaded with
+// keys, the new artifact must carry a signature by one of them. A rejected
+// artifact leaves the current one in force.
+func reloadRuntime(runtime *core.RuntimeInterface, path string) (core.RuntimeSnapshot, error) {
+	artifact, sig, err := core.ReadArtifactFile(path)
+	if err != nil {
+		return core.RuntimeSnapshot{}, err
+	}
+	var generation uint64
+	if sig != nil {
+		generation, err = runtime.SwapVerified(artifact, *sig)
+	} else {
+		generation, err = runtime.Swap(artifact)
+	}
+	if err != nil {
+		return core.RuntimeSnapshot{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return core.RuntimeSnapshot{Generation: generation, ArtifactID: artifact.ID}, nil
+}
+
+// logger implements core.Logger by writing to a stream.
+type logger struct {
+	w io.Writer
+}
+
+func (l logger) Debug(msg string, args ...interface{}) { l.log("DEBUG", msg, args) }
+func (l logger) Info(msg string, args ...interface{})  { l.log("INFO", msg, args) }
+func (l logger) Warn(msg string, args ...interface{})  { l.log("WARN", msg, args) }
+func (l logger) Error(msg string, args ...interface{}) { l.log("ERROR", msg, args) }
+
+func (l logger) log(level, msg string, args []interface{}) {
+	fmt.Fprintf(l.w, "["+level+"] "+msg+"\n", args...)
+}
+
+// makeParent creates the directory path is to be written to, if needed.
+func makeParent(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0o755)
+}
+
+// writeFile writes data to path, creating its directory if needed.
+func writeFile(path string, data []byte) error {
+	if err := makeParent(path); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
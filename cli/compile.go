@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"are/core"
+)
+
+// runCompile implements "are compile": it compiles one or more source files and
+// writes the artifact and its proof. A compilation failure is reported with its
+// stage, violated invariant, and involved claims, and exits with ExitFailure.
+func runCompile(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("compile", "source...", stderr)
+	out := fs.String("o", "artifact.json", "file to write the compiled artifact to")
+	proofOut := fs.String("proof", "proof.json", "file to write the compilation proof to")
+	keyPath := fs.String("sign", "", "Ed25519 private key file; if set, the artifact is signed and the proof signature is written to <proof>.sig")
+	generatedAt := fs.String("generated-at", "", "RFC 3339 time to record as the generation time, for reproducible builds (default now)")
+	verbose := fs.Bool("v", false, "log compilation stages to stderr")
+	if status := parseFlags(fs, args); status >= 0 {
+		return status
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "are compile: no source files")
+		fs.Usage()
+		return ExitUsage
+	}
+
+	compiler := core.NewAuthorityCompiler()
+	if *verbose {
+		compiler.SetLogger(logger{stderr})
+	}
+	if *generatedAt != "" {
+		at, err := time.Parse(time.RFC3339, *generatedAt)
+		if err != nil {
+			fmt.Fprintf(stderr, "are compile: -generated-at: %v\n", err)
+			return ExitUsage
+		}
+		compiler.SetClock(func() time.Time { return at })
+	}
+	var signer *core.Ed25519Signer
+	if *keyPath != "" {
+		var err error
+		if signer, err = core.LoadEd25519Signer(*keyPath); err != nil {
+			fmt.Fprintf(stderr, "are compile: %v\n", err)
+			return ExitFailure
+		}
+	}
+
+	sources, err := loadSources(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "are compile: %v\n", err)
+		return ExitFailure
+	}
+	var result interface{}
+	if len(sources) == 1 {
+		result = compiler.ProcessWithContext(context.Background(), sources[0])
+	} else {
+		result = compiler.ProcessSourcesWithContext(context.Background(), sources)
+	}
+
+	switch r := result.(type) {
+	case core.CompilationSuccess:
+		if err := writeCompilation(r, signer, *out, *proofOut); err != nil {
+			fmt.Fprintf(stderr, "are compile: %v\n", err)
+			return ExitFailure
+		}
+		fmt.Fprintf(stdout, "compiled %s: %d claims from %d sources\n", r.Artifact.ID, len(r.Artifact.Claims), len(r.Artifact.Sources))
+		fmt.Fprintf(stdout, "wrote %s and %s\n", *out, *proofOut)
+		return ExitOK
+	case core.CompilationFailure:
+		printFailure(stderr, r)
+		return ExitFailure
+	default:
+		fmt.Fprintf(stderr, "are compile: unexpected compilation result %T\n", result)
+		return ExitFailure
+	}
+}
+
+// writeCompilation writes the artifact and proof of a successful compilation,
+// signing both if signer is not nil.
+func writeCompilation(r core.CompilationSuccess, signer *core.Ed25519Signer, out, proofOut string) error {
+	var artifactSig *core.Signature
+	if signer != nil {
+		sig, err := core.SignArtifact(signer, r.Artifact)
+		if err != nil {
+			return err
+		}
+		artifactSig = &sig
+	}
+	if err := makeParent(out); err != nil {
+		return err
+	}
+	if err := core.WriteArtifactFile(out, r.Artifact, artifactSig); err != nil {
+		return err
+	}
+	if err := writeFile(proofOut, []byte(r.Proof)); err != nil {
+		return err
+	}
+	if signer == nil {
+		return nil
+	}
+	proofSig, err := core.SignProof(signer, r.Proof)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(proofSig, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(proofOut+".sig", append(data, '\n'))
+}
+
+// printFailure reports a compilation failure in a form meant for people.
+func printFailure(w io.Writer, f core.CompilationFailure) {
+	fmt.Fprintf(w, "compilation failed at stage %s\n", f.FailureStage)
+	fmt.Fprintf(w, "  violated invariant: %s\n", f.ViolatedInvariant)
+	if len(f.InvolvedClaimIDs) == 0 {
+		fmt.Fprintln(w, "  involved claims: none")
+	} else {
+		fmt.Fprintln(w, "  involved claims:")
+		for _, id := range f.InvolvedClaimIDs {
+			fmt.Fprintf(w, "    - %s\n", id)
+		}
+	}
+	if f.FailClosed {
+		fmt.Fprintln(w, "  no artifact was written (fail closed)")
+	}
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"are/core"
+)
+
+// Explanation is everything "are explain" reports about a decision. It is what
+// -json prints.
+type Explanation struct {
+	Result      core.AuthorizationResult `json:"result"`
+	Applicable  []core.Claim             `json:"applicable_claims"`
+	Obligations []core.Claim             `json:"obligations"`
+	Sources     []core.SourceInfo        `json:"sources"`
+	Resolutions []core.ResolutionRecord  `json:"resolutions"`
+}
+
+// explain gathers the explanation of the decision runtime makes for req. Sources
+// are those of the applicable claims, and Resolutions the compile-time removals
+// made by applicable claims.
+func explain(runtime *core.RuntimeInterface, req core.AuthorizationRequest) Explanation {
+	artifact := runtime.GetArtifact()
+	result, obligations := runtime.AuthorizeWithObligations(req)
+	e := Explanation{
+		Result:      result,
+		Applicable:  runtime.AuthorityInfoFor(req).ApplicableClaims,
+		Obligations: obligations,
+		Sources:     []core.SourceInfo{},
+		Resolutions: []core.ResolutionRecord{},
+	}
+	claimIDs := map[string]bool{}
+	sourceIDs := map[string]bool{}
+	for _, claim := range e.Applicable {
+		claimIDs[claim.QualifiedID()] = true
+		sourceIDs[claim.SourceID] = true
+	}
+	for _, source := range artifact.Sources {
+		if sourceIDs[source.ID] {
+			e.Sources = append(e.Sources, source)
+		}
+	}
+	for _, record := range artifact.Resolutions {
+		if claimIDs[record.By] {
+			e.Resolutions = append(e.Resolutions, record)
+		}
+	}
+	return e
+}
+
+// runExplain implements "are explain": it decides one request and prints the
+// reasoning behind the decision.
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	runtime, req, q, status := parseQuery("explain", args, stderr)
+	if status >= 0 {
+		return status
+	}
+	e := explain(runtime, req)
+	if q.json {
+		if err := writeJSON(stdout, e); err != nil {
+			fmt.Fprintf(stderr, "are explain: %v\n", err)
+			return ExitFailure
+		}
+		return ExitOK
+	}
+	printExplanation(stdout, e)
+	return ExitOK
+}
+
+func printExplanation(w io.Writer, e Explanation) {
+	proof := e.Result.Proof
+	fmt.Fprintf(w, "decision:   %s (%s)\n", decisionWord(e.Result.Allowed), proof.Outcome)
+	fmt.Fprintf(w, "reason:     %s\n", e.Result.Reason)
+	fmt.Fprintf(w, "artifact:   %s (generation %d)\n", proof.ArtifactID, e.Result.Generation)
+	fmt.Fprintf(w, "request:    %s %s %s\n", proof.Request.Subject, proof.Request.Action, proof.Request.Resource)
+	if rc := proof.Request.Context; rc != nil {
+		fmt.Fprintf(w, "context:    jurisdiction %s, operation %s, at %s\n",
+			orAny(rc.Jurisdiction), orAny(rc.Operation), rc.Time.Format(time.RFC3339))
+	} else {
+		fmt.Fprintln(w, "context:    none (claim scope not consulted)")
+	}
+	if proof.Strategy != "" {
+		fmt.Fprintf(w, "strategy:   %s\n", proof.Strategy)
+	}
+
+	if proof.Claim != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "deciding claim %s (%s)\n", proof.Claim.ID, proof.Claim.Type)
+		for _, match := range proof.Matches {
+			fmt.Fprintf(w, "  %-9s %s matches %s\n", match.Field, match.Pattern, match.Value)
+		}
+		fmt.Fprintf(w, "  scope     %s\n", formatScope(e.Result.Scope))
+		if source := proof.Source; source != nil {
+			fmt.Fprintf(w, "  source    %s, %s, version %s, precedence rank %d\n", source.ID, source.Type, source.Version, source.PrecedenceRank)
+		}
+		if len(proof.DelegationPath) > 0 {
+			fmt.Fprintf(w, "  delegated %s\n", strings.Join(proof.DelegationPath, " <- "))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "matched claims (%d)\n", len(e.Applicable))
+	for _, claim := range e.Applicable {
+		fmt.Fprintf(w, "  %s %s: %s %s %s, source %s, scope %s\n",
+			claim.ID, claim.Type, claim.Subject, claim.Action, claim.Resource, claim.SourceID, formatScope(claim.Scope))
+	}
+	fmt.Fprintf(w, "overridden claims (%d)\n", len(proof.Overridden))
+	for _, overridden := range proof.Overridden {
+		fmt.Fprintf(w, "  %s %s, source %s: %s\n", overridden.ClaimID, overridden.Type, overridden.SourceID, overridden.Reason)
+	}
+	fmt.Fprintf(w, "obligations (%d)\n", len(e.Obligations))
+	for _, claim := range e.Obligations {
+		fmt.Fprintf(w, "  %s: %s %s %s\n", claim.ID, claim.Subject, claim.Action, claim.Resource)
+	}
+	fmt.Fprintf(w, "sources (%d)\n", len(e.Sources))
+	for _, source := range e.Sources {
+		fmt.Fprintf(w, "  %s %s, version %s: %s\n", source.ID, source.Type, source.Version, source.Name)
+	}
+	if len(e.Resolutions) > 0 {
+		fmt.Fprintf(w, "removed at compile time (%d)\n", len(e.Resolutions))
+		for _, record := range e.Resolutions {
+			fmt.Fprintf(w, "  %s by %s (%s)", record.ClaimID, record.By, record.Edge)
+			if record.Strategy != "" {
+				fmt.Fprintf(w, ", strategy %s, precedence %v over %v", record.Strategy, record.WinnerKey, record.LoserKey)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// formatScope prints a scope on one line; empty fields are unrestricted.
+func formatScope(scope core.Scope) string {
+	jurisdictions := "any jurisdiction"
+	if len(scope.Jurisdictions) > 0 {
+		jurisdictions = "in " + strings.Join(scope.Jurisdictions, ",")
+	}
+	operations := "any operation"
+	if len(scope.Operations) > 0 {
+		operations = "for " + strings.Join(scope.Operations, ",")
+	}
+	window := "any time"
+	if scope.TimeStart != nil || scope.TimeEnd != nil {
+		window = "during " + formatInstant(scope.TimeStart) + ".." + formatInstant(scope.TimeEnd)
+	}
+	return jurisdictions + ", " + operations + ", " + window
+}
+
+func formatInstant(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func orAny(value string) string {
+	if value == "" {
+		return "any"
+	}
+	return value
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"are/core"
+	"are/grpcserver"
+	"are/server"
+)
+
+// runServe implements "are serve".
+func runServe(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("serve", "", stderr)
+	addr := fs.String("addr", ":8080", "address to serve the decision API on")
+	grpcAddr := fs.String("grpc-addr", "", "address to serve the gRPC DecisionService on (disabled if empty)")
+	artifactPath := fs.String("artifact", "", "compiled artifact file to enforce (required)")
+	keys := fs.String("keys", "", "comma-separated Ed25519 public key files; if set, the artifact must be signed by one of them")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 10*time.Second, "how long to wait for in-flight requests on shutdown")
+	if status := parseFlags(fs, args); status >= 0 {
+		return status
+	}
+	if *artifactPath == "" {
+		fmt.Fprintln(stderr, "are serve: -artifact is required")
+		fs.Usage()
+		return ExitUsage
+	}
+
+	log := logger{stdout}
+	if err := serve(log, *addr, *grpcAddr, *artifactPath, *keys, *shutdownTimeout); err != nil {
+		log.Error("%v", err)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// serve enforces the artifact at artifactPath over HTTP, and gRPC if grpcAddr is
+// set, until SIGINT or SIGTERM, then stops accepting requests and lets in-flight
+// ones finish. On SIGHUP the artifact file is read again and, if it passes the
+// same checks as at startup, swapped in without dropping requests.
+func serve(logger core.Logger, addr, grpcAddr, artifactPath, keys string, shutdownTimeout time.Duration) error {
+	runtime, err := loadRuntime(artifactPath, keys)
+	if err != nil {
+		return err
+	}
+	artifact := runtime.GetArtifact()
+
+	handler := server.New(runtime)
+	httpServer := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	errc := make(chan error, 1)
+	go func() {
+		logger.Info("Serving artifact %s (%d claims) on %s", artifact.ID, len(artifact.Claims), addr)
+		errc <- httpServer.ListenAndServe()
+	}()
+
+	var grpcServer *grpc.Server
+	grpcErrc := make(chan error, 1)
+	if grpcAddr != "" {
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			httpServer.Close()
+			return err
+		}
+		grpcServer = grpc.NewServer()
+		grpcserver.New(runtime).Register(grpcServer)
+		go func() {
+			logger.Info("Serving gRPC DecisionService on %s", grpcAddr)
+			grpcErrc <- grpcServer.Serve(listener)
+		}()
+	}
+
+wait:
+	for {
+		select {
+		case <-hangup:
+			if snapshot, err := reloadRuntime(runtime, artifactPath); err != nil {
+				logger.Error("Reload failed, still enforcing generation %d: %v", runtime.Generation(), err)
+			} else {
+				logger.Info("Reloaded artifact %s as generation %d", snapshot.ArtifactID, snapshot.Generation)
+			}
+		case err := <-errc:
+			if grpcServer != nil {
+				grpcServer.Stop()
+			}
+			return err
+		case err := <-grpcErrc:
+			httpServer.Close()
+			return err
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	logger.Info("Shutting down")
+	handler.SetReady(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+		}
+	}
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"are/core"
+	"are/policytest"
+)
+
+// runTest implements "are test": it compiles the sources of each policy test
+// suite, or loads -artifact instead, and evaluates the suite's cases against the
+// result. It exits with ExitFailure if any case fails or any suite cannot be run.
+func runTest(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("test", "suite...", stderr)
+	artifactPath := fs.String("artifact", "", "compiled artifact file to test instead of compiling each suite's sources")
+	keys := fs.String("keys", "", "comma-separated Ed25519 public key files; if set, -artifact must be signed by one of them")
+	verbose := fs.Bool("v", false, "list passing cases too")
+	if status := parseFlags(fs, args); status >= 0 {
+		return status
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "are test: no suite files")
+		fs.Usage()
+		return ExitUsage
+	}
+
+	var shared *core.RuntimeInterface
+	if *artifactPath != "" {
+		runtime, err := loadRuntime(*artifactPath, *keys)
+		if err != nil {
+			fmt.Fprintf(stderr, "are test: %v\n", err)
+			return ExitFailure
+		}
+		shared = runtime
+	}
+
+	status := ExitOK
+	for _, path := range fs.Args() {
+		suite, err := policytest.ParseFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "are test: %v\n", err)
+			status = ExitFailure
+			continue
+		}
+		runtime := shared
+		if runtime == nil {
+			if runtime, err = compileSuite(suite, stderr); err != nil {
+				fmt.Fprintf(stdout, "FAIL\t%s\t%v\n", suite.Name, err)
+				status = ExitFailure
+				continue
+			}
+		}
+		report := suite.Run(runtime)
+		report.Write(stdout, *verbose)
+		if report.Failed() > 0 {
+			status = ExitFailure
+		}
+	}
+	return status
+}
+
+// compileSuite compiles the sources of a suite. A compilation failure is printed
+// to stderr.
+func compileSuite(suite *policytest.Suite, stderr io.Writer) (*core.RuntimeInterface, error) {
+	if len(suite.Sources) == 0 {
+		return nil, fmt.Errorf("suite lists no sources and no -artifact was given")
+	}
+	sources, err := loadSources(suite.SourcePaths())
+	if err != nil {
+		return nil, err
+	}
+	switch r := core.NewAuthorityCompiler().ProcessSources(sources).(type) {
+	case core.CompilationSuccess:
+		return core.NewRuntimeInterface(r.Artifact), nil
+	case core.CompilationFailure:
+		printFailure(stderr, r)
+		return nil, fmt.Errorf("compilation failed at stage %s", r.FailureStage)
+	default:
+		return nil, fmt.Errorf("unexpected compilation result %T", r)
+	}
+}
@@ -2,7 +2,6 @@
 package core
 
 import (
-	"sync"
 	"time"
 )
 
@@ -11,11 +10,11 @@ import (
 type AuthorityType string
 
 const (
-	Sovereign     AuthorityType = "sovereign"
-	Legal         AuthorityType = "legal"
-	Regulatory    AuthorityType = "regulatory"
+	Sovereign      AuthorityType = "sovereign"
+	Legal          AuthorityType = "legal"
+	Regulatory     AuthorityType = "regulatory"
 	Organizational AuthorityType = "organizational"
-	Contractual   AuthorityType = "contractual"
+	Contractual    AuthorityType = "contractual"
 )
 
 // ClaimType represents semantic types of authority claims.
@@ -34,33 +33,44 @@ const (
 type EdgeType string
 
 const (
-	Delegates EdgeType = "delegates"
-	Revokes   EdgeType = "revokes"
+	Delegates  EdgeType = "delegates"
+	Revokes    EdgeType = "revokes"
 	Supersedes EdgeType = "supersedes"
+	Overrides  EdgeType = "overrides"
 )
 
 // Scope represents the jurisdictional, temporal, and operational boundaries of authority.
 // Empty sets are treated as universal scope (applies everywhere/always/to all operations).
 // This is an intentional design decision for fail-open scope semantics.
 type Scope struct {
-	Jurisdictions []string
-	TimeStart     *time.Time // ISO format date-time
-	TimeEnd       *time.Time // ISO format date-time
-	Operations    []string
+	Jurisdictions []string   `json:"jurisdictions"`
+	TimeStart     *time.Time `json:"time_start,omitempty"` // ISO format date-time
+	TimeEnd       *time.Time `json:"time_end,omitempty"`   // ISO format date-time
+	Operations    []string   `json:"operations"`
 }
 
 // Claim represents a single authority claim (permission, prohibition, obligation, delegation).
+// Position is the claim's declaration order across the compiled sources; the
+// first-applicable strategy decides by it, so it is part of the signed content.
 // Claims are immutable once created; modifications require creating new claims.
 // Thread-safe for concurrent read access; write operations require external synchronization.
 type Claim struct {
-	ID         string
-	Type       ClaimType
-	Subject    string // e.g., role, user, system
-	Action     string // e.g., read, write, execute
-	Resource   string // e.g., file path, API endpoint
-	Scope      Scope
-	Conditions map[string]interface{}
-	SourceID   string // Reference to AuthoritySource
+	ID         string                 `json:"id"`
+	Type       ClaimType              `json:"type"`
+	Subject    string                 `json:"subject"`  // e.g., role, user, system
+	Action     string                 `json:"action"`   // e.g., read, write, execute
+	Resource   string                 `json:"resource"` // e.g., file path, API endpoint
+	Scope      Scope                  `json:"scope"`
+	Conditions map[string]interface{} `json:"conditions,omitempty"`
+	SourceID   string                 `json:"source_id"` // Reference to AuthoritySource
+	Position   int                    `json:"position"`
+}
+
+// QualifiedID returns the claim's ID qualified by its source, "source_id:claim_id".
+// Claim IDs are only unique within a source, so graph nodes and edges, time slices,
+// and resolution records refer to claims by their qualified IDs.
+func (c Claim) QualifiedID() string {
+	return ClaimRef{SourceID: c.SourceID, ClaimID: c.ID}.String()
 }
 
 // AuthoritySource represents the origin of authority.
@@ -74,38 +84,57 @@ type AuthoritySource struct {
 	Metadata    map[string]interface{}
 }
 
+// SourceInfo records the identity and precedence-relevant attributes of an
+// AuthoritySource that contributed claims to an artifact.
+type SourceInfo struct {
+	ID      string        `json:"id"`
+	Type    AuthorityType `json:"type"`
+	Name    string        `json:"name"`
+	Version string        `json:"version"`
+}
+
 // AuthorityGraph represents formal structure encoding precedence, inheritance, delegation, and revocation.
+// Nodes are keyed by qualified claim ID (see Claim.QualifiedID).
 // Graphs must be acyclic; cyclic graphs fail validation.
-// Thread-safe for concurrent read access when protected by parent artifact's mutex.
+// Graphs are immutable once compiled and safe for concurrent reads.
 type AuthorityGraph struct {
-	Nodes map[string]Claim
-	Edges []Edge
+	Nodes map[string]Claim `json:"nodes"`
+	Edges []Edge           `json:"edges"`
 }
 
 // Edge represents a relationship between claims in the graph.
 // Edges are directional: FromID -> ToID with semantic meaning defined by EdgeType.
-// - Delegates: FromID delegates authority to ToID
-// - Revokes: FromID revokes ToID
-// - Supersedes: FromID supersedes ToID
+// Both ends are qualified claim IDs.
+//   - Delegates: FromID delegates authority to ToID
+//   - Revokes: FromID revokes ToID
+//   - Supersedes: FromID supersedes ToID
+//   - Overrides: FromID takes precedence over ToID where their patterns overlap
+//     (added by conflict resolution, never declared in sources)
 type Edge struct {
-	FromID   string
-	ToID     string
-	EdgeType EdgeType
+	FromID   string   `json:"from"`
+	ToID     string   `json:"to"`
+	EdgeType EdgeType `json:"type"`
 }
 
 // AuthorityArtifact represents compiled output that binds systems to authority.
 // Artifacts are the primary output of the compilation pipeline.
-// Thread-safe for concurrent read access; use RLock/RUnlock for reads.
+// SourceID is set for single-source artifacts; Sources lists every contributing source.
+// Timeline lists the claims whose time windows conflict resolution narrowed,
+// Resolutions the claims it removed and why, and Strategies the conflict
+// strategies bound to sources and resource namespaces.
+// Artifacts are plain values: they may be copied and marshalled freely, and are
+// safe for concurrent reads as long as no goroutine mutates them.
+// See MarshalArtifact for the on-disk format.
 type AuthorityArtifact struct {
-	ID          string         `json:"id"`
-	SourceID    string         `json:"source_id"`
-	Claims      []Claim        `json:"claims"`
-	Graph       AuthorityGraph `json:"graph"` // Always required, even if empty
-	GeneratedAt time.Time      `json:"generated_at"`
-
-	// mu protects concurrent access to artifact fields.
-	// Use RLock for reads, Lock for writes.
-	mu sync.RWMutex
+	ID          string             `json:"id"`
+	SourceID    string             `json:"source_id"`
+	Sources     []SourceInfo       `json:"sources"`
+	Claims      []Claim            `json:"claims"`
+	Graph       AuthorityGraph     `json:"graph"` // Always required, even if empty
+	Timeline    []TimeSlice        `json:"timeline,omitempty"`
+	Resolutions []ResolutionRecord `json:"resolutions,omitempty"`
+	Strategies  []StrategyBinding  `json:"strategies,omitempty"`
+	GeneratedAt time.Time          `json:"generated_at"`
 }
 
 // CompilationSuccess represents successful compilation outcome.
@@ -116,10 +145,10 @@ type CompilationSuccess struct {
 
 // CompilationFailure represents failed compilation outcome.
 type CompilationFailure struct {
-	FailureStage     string   // ingestion, validation, resolution, compilation
+	FailureStage      string // ingestion, validation, resolution, compilation
 	ViolatedInvariant string
-	InvolvedClaimIDs []string
-	FailClosed       bool
+	InvolvedClaimIDs  []string
+	FailClosed        bool
 }
 
 // AuthorityTypeOrder returns the precedence order for authority types.
@@ -157,9 +186,9 @@ func IsValidClaimType(t ClaimType) bool {
 // IsValidEdgeType checks if an edge type is valid.
 func IsValidEdgeType(t EdgeType) bool {
 	switch t {
-	case Delegates, Revokes, Supersedes:
+	case Delegates, Revokes, Supersedes, Overrides:
 		return true
 	default:
 		return false
 	}
-}
\ No newline at end of file
+}
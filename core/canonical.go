@@ -0,0 +1,223 @@
+package core
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// canonicalArtifact is the fixed-layout encoding of an artifact used for signing
+// and content addressing. It is independent of the Go struct layout so that
+// adding fields to AuthorityArtifact never silently changes what is signed.
+type canonicalArtifact struct {
+	ID          string            `json:"id"`
+	SourceID    string            `json:"source_id"`
+	Sources     []SourceInfo      `json:"sources"`
+	Claims      []canonicalClaim  `json:"claims"`
+	Nodes       []string          `json:"nodes"`
+	Edges       []canonicalEdge   `json:"edges"`
+	Timeline    []canonicalSlice  `json:"timeline"`
+	Resolutions []canonicalRecord `json:"resolutions"`
+	Strategies  []StrategyBinding `json:"strategies"`
+	GeneratedAt string            `json:"generated_at"`
+}
+
+type canonicalClaim struct {
+	ID         string                 `json:"id"`
+	Type       ClaimType              `json:"type"`
+	Subject    string                 `json:"subject"`
+	Action     string                 `json:"action"`
+	Resource   string                 `json:"resource"`
+	Scope      canonicalScope         `json:"scope"`
+	Conditions map[string]interface{} `json:"conditions"`
+	SourceID   string                 `json:"source_id"`
+	Position   int                    `json:"position"`
+}
+
+type canonicalScope struct {
+	Jurisdictions []string `json:"jurisdictions"`
+	TimeStart     string   `json:"time_start"`
+	TimeEnd       string   `json:"time_end"`
+	Operations    []string `json:"operations"`
+}
+
+type canonicalSlice struct {
+	ClaimID   string   `json:"claim_id"`
+	OriginID  string   `json:"origin_id"`
+	TimeStart string   `json:"time_start"`
+	TimeEnd   string   `json:"time_end"`
+	CutBy     []string `json:"cut_by"`
+}
+
+type canonicalRecord struct {
+	ClaimID   string   `json:"claim_id"`
+	By        string   `json:"by"`
+	Edge      EdgeType `json:"edge"`
+	Strategy  string   `json:"strategy"`
+	WinnerKey []int    `json:"winner_key"`
+	LoserKey  []int    `json:"loser_key"`
+}
+
+type canonicalEdge struct {
+	FromID   string   `json:"from"`
+	ToID     string   `json:"to"`
+	EdgeType EdgeType `json:"type"`
+}
+
+// CanonicalArtifact returns the canonical byte encoding of an artifact: compact JSON
+// with sources, claims, nodes, edges, timeline, resolutions, and strategies sorted,
+// map keys sorted, empty sets encoded as [], and times in UTC RFC3339Nano. Equal artifacts always encode to
+// equal bytes, which makes the encoding suitable for signing and hashing.
+func CanonicalArtifact(artifact AuthorityArtifact) ([]byte, error) {
+	return json.Marshal(canonicalize(artifact))
+}
+
+// ContentID derives an artifact ID from its content: "sha256:" followed by the hex
+// SHA-256 of the canonical encoding of its sources, claims, and graph. ID and
+// GeneratedAt are excluded, so the same resolved authority always has the same ID
+// regardless of when it was compiled.
+func ContentID(artifact AuthorityArtifact) (string, error) {
+	content := canonicalize(artifact)
+	content.ID = ""
+	content.GeneratedAt = ""
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return payloadDigest(encoded), nil
+}
+
+func canonicalize(artifact AuthorityArtifact) canonicalArtifact {
+	sources := append([]SourceInfo{}, artifact.Sources...)
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].ID < sources[j].ID
+	})
+
+	claims := make([]canonicalClaim, 0, len(artifact.Claims))
+	for _, claim := range artifact.Claims {
+		claims = append(claims, canonicalClaim{
+			ID:         claim.ID,
+			Type:       claim.Type,
+			Subject:    claim.Subject,
+			Action:     claim.Action,
+			Resource:   claim.Resource,
+			Scope:      canonicalizeScope(claim.Scope),
+			Conditions: nilIfEmpty(claim.Conditions),
+			SourceID:   claim.SourceID,
+			Position:   claim.Position,
+		})
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		if claims[i].SourceID != claims[j].SourceID {
+			return claims[i].SourceID < claims[j].SourceID
+		}
+		return claims[i].ID < claims[j].ID
+	})
+
+	nodes := make([]string, 0, len(artifact.Graph.Nodes))
+	for id := range artifact.Graph.Nodes {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+
+	edges := make([]canonicalEdge, 0, len(artifact.Graph.Edges))
+	for _, edge := range artifact.Graph.Edges {
+		edges = append(edges, canonicalEdge{FromID: edge.FromID, ToID: edge.ToID, EdgeType: edge.EdgeType})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].FromID != edges[j].FromID {
+			return edges[i].FromID < edges[j].FromID
+		}
+		if edges[i].ToID != edges[j].ToID {
+			return edges[i].ToID < edges[j].ToID
+		}
+		return edges[i].EdgeType < edges[j].EdgeType
+	})
+
+	timeline := make([]canonicalSlice, 0, len(artifact.Timeline))
+	for _, slice := range artifact.Timeline {
+		timeline = append(timeline, canonicalSlice{
+			ClaimID:   slice.ClaimID,
+			OriginID:  slice.OriginID,
+			TimeStart: canonicalTime(slice.TimeStart),
+			TimeEnd:   canonicalTime(slice.TimeEnd),
+			CutBy:     nonNilStrings(slice.CutBy),
+		})
+	}
+	sort.Slice(timeline, func(i, j int) bool {
+		return timeline[i].ClaimID < timeline[j].ClaimID
+	})
+
+	records := append([]ResolutionRecord{}, artifact.Resolutions...)
+	sortResolutions(records)
+	resolutions := make([]canonicalRecord, 0, len(records))
+	for _, record := range records {
+		resolutions = append(resolutions, canonicalRecord{
+			ClaimID:   record.ClaimID,
+			By:        record.By,
+			Edge:      record.Edge,
+			Strategy:  record.Strategy,
+			WinnerKey: nonNilInts(record.WinnerKey),
+			LoserKey:  nonNilInts(record.LoserKey),
+		})
+	}
+
+	strategies := append([]StrategyBinding{}, artifact.Strategies...)
+	sort.Slice(strategies, func(i, j int) bool {
+		if strategies[i].SourceID != strategies[j].SourceID {
+			return strategies[i].SourceID < strategies[j].SourceID
+		}
+		return strategies[i].Namespace < strategies[j].Namespace
+	})
+
+	return canonicalArtifact{
+		ID:          artifact.ID,
+		SourceID:    artifact.SourceID,
+		Sources:     sources,
+		Claims:      claims,
+		Nodes:       nodes,
+		Edges:       edges,
+		Timeline:    timeline,
+		Resolutions: resolutions,
+		Strategies:  strategies,
+		GeneratedAt: canonicalTime(&artifact.GeneratedAt),
+	}
+}
+
+func canonicalizeScope(scope Scope) canonicalScope {
+	return canonicalScope{
+		Jurisdictions: nonNilStrings(scope.Jurisdictions),
+		TimeStart:     canonicalTime(scope.TimeStart),
+		TimeEnd:       canonicalTime(scope.TimeEnd),
+		Operations:    nonNilStrings(scope.Operations),
+	}
+}
+
+func canonicalTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nonNilInts(values []int) []int {
+	if values == nil {
+		return []int{}
+	}
+	return values
+}
+
+// nilIfEmpty encodes empty conditions like absent ones, as MarshalArtifact does.
+func nilIfEmpty(conditions map[string]interface{}) map[string]interface{} {
+	if len(conditions) == 0 {
+		return nil
+	}
+	return conditions
+}
@@ -3,14 +3,14 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // semverRegex matches semantic version strings (e.g., "1.2.3", "v2.0.0-beta").
@@ -35,16 +35,18 @@ func (l *DefaultLogger) Error(msg string, args ...interface{}) {}
 // AuthorityCompiler transforms authority sources into executable artifacts.
 // Thread-safe for concurrent use across multiple goroutines.
 type AuthorityCompiler struct {
-	sources map[string]AuthoritySource
-	mu      sync.RWMutex
-	logger  Logger
+	namespaces map[string]string // resource namespace -> conflict strategy
+	mu         sync.RWMutex
+	logger     Logger
+	clock      func() time.Time
 }
 
 // NewAuthorityCompiler creates a new thread-safe AuthorityCompiler instance.
 func NewAuthorityCompiler() *AuthorityCompiler {
 	return &AuthorityCompiler{
-		sources: make(map[string]AuthoritySource),
-		logger:  &DefaultLogger{},
+		namespaces: make(map[string]string),
+		logger:     &DefaultLogger{},
+		clock:      time.Now,
 	}
 }
 
@@ -55,6 +57,39 @@ func (c *AuthorityCompiler) SetLogger(logger Logger) {
 	c.logger = logger
 }
 
+// SetClock sets the clock used to stamp GeneratedAt. Pinning it makes repeated
+// builds of the same sources produce byte-identical artifacts and proofs.
+func (c *AuthorityCompiler) SetClock(clock func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clock
+}
+
+// SetNamespaceStrategy binds a registered conflict strategy to a resource namespace,
+// given as a resource pattern such as "/finance/*". It governs conflicts between
+// claims whose resources all fall under the namespace, and runtime requests for
+// resources under it, taking priority over strategies bound by sources.
+func (c *AuthorityCompiler) SetNamespaceStrategy(namespace, strategy string) error {
+	if namespace == "" {
+		return newValidationError("namespace", "namespace is required", nil)
+	}
+	if _, err := LookupConflictStrategy(strategy); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.namespaces[namespace] = strategy
+	return nil
+}
+
+// now returns the current time from the configured clock, in UTC.
+func (c *AuthorityCompiler) now() time.Time {
+	c.mu.RLock()
+	clock := c.clock
+	c.mu.RUnlock()
+	return clock().UTC()
+}
+
 // Ingest ingests an authority source and normalizes it to AIR.
 // Returns an error if the source is invalid.
 func (c *AuthorityCompiler) Ingest(source AuthoritySource) (AuthorityArtifact, error) {
@@ -63,38 +98,60 @@ func (c *AuthorityCompiler) Ingest(source AuthoritySource) (AuthorityArtifact, e
 	}
 
 	artifact := AuthorityArtifact{
-		ID:          generateUUID(),
 		SourceID:    source.ID,
+		Sources:     sourceInfos([]AuthoritySource{source}),
 		Claims:      []Claim{},
 		Graph:       AuthorityGraph{Nodes: make(map[string]Claim), Edges: []Edge{}},
-		GeneratedAt: time.Now().UTC(),
+		GeneratedAt: c.now(),
+	}
+	id, err := ContentID(artifact)
+	if err != nil {
+		return AuthorityArtifact{}, err
 	}
+	artifact.ID = id
 	return artifact, nil
 }
 
 // Normalize converts authority input into canonical AIR.
-// Thread-safe: acquires write lock to store source.
+// Thread-safe for concurrent use.
 func (c *AuthorityCompiler) Normalize(ctx context.Context, source AuthoritySource) (AuthorityArtifact, error) {
+	return c.NormalizeSources(ctx, []AuthoritySource{source})
+}
+
+// NormalizeSources converts several authority sources into a single canonical AIR.
+// Every claim keeps the ID of the source it was declared in, so precedence can be
+// resolved across sources. Source IDs must be unique within the set.
+// Thread-safe for concurrent use.
+func (c *AuthorityCompiler) NormalizeSources(ctx context.Context, sources []AuthoritySource) (AuthorityArtifact, error) {
 	if err := ctx.Err(); err != nil {
 		return AuthorityArtifact{}, err
 	}
-
-	if source.ID == "" {
-		return AuthorityArtifact{}, ErrEmptySourceID
-	}
-	if err := validateAuthoritySource(source); err != nil {
-		return AuthorityArtifact{}, err
+	if len(sources) == 0 {
+		return AuthorityArtifact{}, ErrNoSources
 	}
 
-	// Store source for later precedence resolution (thread-safe)
-	c.mu.Lock()
-	c.sources[source.ID] = source
-	c.mu.Unlock()
+	seenSourceIDs := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if source.ID == "" {
+			return AuthorityArtifact{}, ErrEmptySourceID
+		}
+		if seenSourceIDs[source.ID] {
+			return AuthorityArtifact{}, newValidationError("source.id", fmt.Sprintf("duplicate source ID: %s", source.ID), nil)
+		}
+		seenSourceIDs[source.ID] = true
+		if err := validateAuthoritySource(source); err != nil {
+			return AuthorityArtifact{}, err
+		}
+	}
 
 	claims := []Claim{}
 	var parseErrors []error
 
-	if claimsData, ok := source.Metadata["claims"].([]interface{}); ok {
+	for _, source := range sources {
+		claimsData, ok := source.Metadata["claims"].([]interface{})
+		if !ok {
+			continue
+		}
 		for _, claimData := range claimsData {
 			if claimDict, ok := claimData.(map[string]interface{}); ok {
 				claim, err := c.parseClaim(claimDict, source.ID)
@@ -102,6 +159,7 @@ func (c *AuthorityCompiler) Normalize(ctx context.Context, source AuthoritySourc
 					parseErrors = append(parseErrors, err)
 					continue
 				}
+				claim.Position = len(claims)
 				claims = append(claims, claim)
 			}
 		}
@@ -115,22 +173,93 @@ func (c *AuthorityCompiler) Normalize(ctx context.Context, source AuthoritySourc
 		}
 	}
 
-	graph := c.buildGraph(claims)
+	sourceTypes := make(map[string]AuthorityType, len(sources))
+	for _, source := range sources {
+		sourceTypes[source.ID] = source.Type
+	}
+	if err := checkClaimReferences(claims, sourceTypes); err != nil {
+		return AuthorityArtifact{}, err
+	}
 
-	return AuthorityArtifact{
-		ID:          generateUUID(),
-		SourceID:    source.ID,
+	graph := c.buildGraph(claims, nil)
+
+	bindings, err := c.strategyBindings(sources)
+	if err != nil {
+		return AuthorityArtifact{}, err
+	}
+
+	artifact := AuthorityArtifact{
+		Sources:     sourceInfos(sources),
 		Claims:      claims,
 		Graph:       graph,
-		GeneratedAt: time.Now().UTC(),
-	}, nil
+		Strategies:  bindings,
+		GeneratedAt: c.now(),
+	}
+	if len(sources) == 1 {
+		artifact.SourceID = sources[0].ID
+	}
+	id, err := ContentID(artifact)
+	if err != nil {
+		return AuthorityArtifact{}, &CompilationError{
+			Stage:            "normalization",
+			Message:          "claims cannot be canonically encoded",
+			InvolvedClaimIDs: getClaimIDs(claims),
+			Err:              err,
+		}
+	}
+	artifact.ID = id
+	return artifact, nil
+}
+
+// strategyBindings collects the conflict strategies bound by the sources' metadata
+// and the compiler's namespaces, sorted for deterministic output.
+func (c *AuthorityCompiler) strategyBindings(sources []AuthoritySource) ([]StrategyBinding, error) {
+	bindings := []StrategyBinding{}
+	for _, source := range sources {
+		value, ok := source.Metadata[StrategyMetadataKey]
+		if !ok {
+			continue
+		}
+		name, ok := value.(string)
+		if !ok {
+			return nil, newValidationError("metadata."+StrategyMetadataKey, fmt.Sprintf("source %s: conflict strategy must be a string", source.ID), ErrUnknownStrategy)
+		}
+		if _, err := LookupConflictStrategy(name); err != nil {
+			return nil, newValidationError("metadata."+StrategyMetadataKey, fmt.Sprintf("source %s binds an unregistered strategy", source.ID), err)
+		}
+		bindings = append(bindings, StrategyBinding{SourceID: source.ID, Strategy: name})
+	}
+
+	c.mu.RLock()
+	for namespace, strategy := range c.namespaces {
+		bindings = append(bindings, StrategyBinding{Namespace: namespace, Strategy: strategy})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].SourceID != bindings[j].SourceID {
+			return bindings[i].SourceID < bindings[j].SourceID
+		}
+		return bindings[i].Namespace < bindings[j].Namespace
+	})
+	return bindings, nil
 }
 
 func (c *AuthorityCompiler) parseClaim(claimDict map[string]interface{}, sourceID string) (Claim, error) {
+	return ParseClaim(claimDict, sourceID)
+}
+
+// ParseClaim parses one claim definition, as found in a source's Metadata["claims"],
+// applying the same checks as Normalize. Front ends use it to attribute parse errors
+// to positions in their own input before compilation.
+func ParseClaim(claimDict map[string]interface{}, sourceID string) (Claim, error) {
 	id, ok := claimDict["id"].(string)
 	if !ok || id == "" {
 		return Claim{}, newValidationError("id", "claim ID is required", nil)
 	}
+	if strings.Contains(id, ":") {
+		return Claim{}, newValidationError("id", fmt.Sprintf("claim ID %s contains ':', which separates source and claim in references", id), ErrInvalidClaim)
+	}
 	claimType, ok := claimDict["type"].(string)
 	if !ok || claimType == "" {
 		return Claim{}, newValidationError("type", "claim type is required", nil)
@@ -170,51 +299,32 @@ func (c *AuthorityCompiler) parseClaim(claimDict map[string]interface{}, sourceI
 	}, nil
 }
 
-func (c *AuthorityCompiler) buildGraph(claims []Claim) AuthorityGraph {
+// buildGraph builds the graph of claims and the edges their references produce.
+// origins maps the qualified IDs of time slices to that of the claim each slice
+// was cut from, so edges to a sliced claim are carried over to all of its slices.
+func (c *AuthorityCompiler) buildGraph(claims []Claim, origins map[string]string) AuthorityGraph {
 	nodes := make(map[string]Claim)
 	edges := []Edge{}
 
 	// First pass: add all nodes
 	for _, claim := range claims {
-		nodes[claim.ID] = claim
+		nodes[claim.QualifiedID()] = claim
 	}
 
-	// Second pass: add edges (now all nodes exist)
+	// Second pass: add edges (now all nodes exist).
+	// References that no longer resolve (e.g. to revoked claims) are dropped here;
+	// checkClaimReferences rejects them at normalization.
+	index := newClaimRefIndex(claims, origins)
 	for _, claim := range claims {
-		if claim.Conditions != nil {
-			if delegatesTo, ok := claim.Conditions["delegates_to"].(string); ok {
-				if _, exists := nodes[delegatesTo]; exists {
-					edges = append(edges, Edge{
-						FromID:   claim.ID,
-						ToID:     delegatesTo,
-						EdgeType: Delegates,
-					})
-				}
-			}
-
-			if revokes, ok := claim.Conditions["revokes"].(string); ok {
-				if _, exists := nodes[revokes]; exists {
-					edges = append(edges, Edge{
-						FromID:   claim.ID,
-						ToID:     revokes,
-						EdgeType: Revokes,
-					})
-				}
-			}
-
-			if supersedes, ok := claim.Conditions["supersedes"].(string); ok {
-				if _, exists := nodes[supersedes]; exists {
-					edges = append(edges, Edge{
-						FromID:   claim.ID,
-						ToID:     supersedes,
-						EdgeType: Supersedes,
-					})
-				}
-			}
-		}
+		edges = append(edges, index.claimEdges(claim)...)
 	}
 
-	// Sort edges for deterministic output
+	sortEdges(edges)
+	return AuthorityGraph{Nodes: nodes, Edges: edges}
+}
+
+// sortEdges sorts edges for deterministic output.
+func sortEdges(edges []Edge) {
 	sort.Slice(edges, func(i, j int) bool {
 		if edges[i].FromID != edges[j].FromID {
 			return edges[i].FromID < edges[j].FromID
@@ -224,8 +334,6 @@ func (c *AuthorityCompiler) buildGraph(claims []Claim) AuthorityGraph {
 		}
 		return edges[i].EdgeType < edges[j].EdgeType
 	})
-
-	return AuthorityGraph{Nodes: nodes, Edges: edges}
 }
 
 // Validate validates the AIR for structural correctness and scope consistency.
@@ -242,189 +350,61 @@ func (c *AuthorityCompiler) ResolveConflicts(ctx context.Context, artifact Autho
 	}
 
 	// First, handle revocations and supersessions
-	artifact = c.applyRevocations(artifact)
-	artifact = c.applySupersessions(artifact)
+	artifact, revoked := c.removeAmended(artifact, Revokes)
+	artifact, superseded := c.removeAmended(artifact, Supersedes)
 
 	// Then handle remaining conflicts via precedence
-	conflicts := c.findConflicts(artifact.Claims)
-
-	for _, conflictGroup := range conflicts {
-		winner, err := c.applyPrecedence(conflictGroup, artifact)
-		if err != nil {
-			return AuthorityArtifact{}, err
-		}
-		if winner == nil {
-			claimIDs := make([]string, len(conflictGroup))
-			for i, claim := range conflictGroup {
-				claimIDs[i] = claim.ID
-			}
-			return AuthorityArtifact{}, &ConflictError{
-				ClaimIDs: claimIDs,
-				Message:  "unresolvable conflict - failing closed",
-			}
-		}
-
-		losingClaimIDs := make(map[string]bool)
-		for _, claim := range conflictGroup {
-			if claim.ID != winner.ID {
-				losingClaimIDs[claim.ID] = true
-			}
-		}
-
-		newClaims := []Claim{}
-		for _, claim := range artifact.Claims {
-			if !losingClaimIDs[claim.ID] {
-				newClaims = append(newClaims, claim)
-			}
-		}
-		artifact.Claims = newClaims
+	resolution, err := c.resolveOverlaps(artifact)
+	if err != nil {
+		return AuthorityArtifact{}, err
 	}
 
-	artifact.Graph = c.buildGraph(artifact.Claims)
+	artifact.Claims = resolution.claims(artifact.Claims)
+	artifact.Graph = c.buildGraph(artifact.Claims, resolution.origins())
+	artifact.Graph.Edges = append(artifact.Graph.Edges, resolution.overrideEdges()...)
+	sortEdges(artifact.Graph.Edges)
+	artifact.Timeline = resolution.timeline
+	artifact.Resolutions = append(append(revoked, superseded...), resolution.records...)
+	sortResolutions(artifact.Resolutions)
 	return artifact, nil
 }
 
-func (c *AuthorityCompiler) findConflicts(claims []Claim) [][]Claim {
-	conflicts := [][]Claim{}
-	grouped := make(map[string][]Claim)
-
-	for _, claim := range claims {
-		// Skip delegation and obligation - they don't conflict with permissions/prohibitions
-		if claim.Type == Delegation || claim.Type == Obligation {
-			continue
-		}
-
-		key := fmt.Sprintf("%s:%s:%s", claim.Subject, claim.Action, claim.Resource)
-		grouped[key] = append(grouped[key], claim)
-	}
-
-	// Only conflicting if there are DIFFERENT claim types (permission vs prohibition)
-	// or multiple claims of the same type (which one wins?)
-	// Sort keys for deterministic iteration
-	keys := make([]string, 0, len(grouped))
-	for k := range grouped {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	for _, key := range keys {
-		group := grouped[key]
-		if len(group) > 1 {
-			types := make(map[ClaimType]bool)
-			for _, c := range group {
-				types[c.Type] = true
-			}
-			if len(types) > 1 || len(group) > 1 {
-				conflicts = append(conflicts, group)
-			}
-		}
-	}
-
-	return conflicts
-}
-
-func (c *AuthorityCompiler) applyPrecedence(claims []Claim, artifact AuthorityArtifact) (*Claim, error) {
-	if len(claims) == 0 {
-		return nil, nil
-	}
-
-	authorityOrder := AuthorityTypeOrder()
-
-	// Thread-safe read of sources
-	c.mu.RLock()
-	sourcesCopy := make(map[string]AuthoritySource, len(c.sources))
-	for k, v := range c.sources {
-		sourcesCopy[k] = v
-	}
-	c.mu.RUnlock()
-
-	for _, claim := range claims {
-		source, exists := sourcesCopy[claim.SourceID]
-		if !exists {
-			return nil, newValidationError("source_id", fmt.Sprintf("unknown source for claim %s: %s", claim.ID, claim.SourceID), nil)
-		}
-		if !IsValidAuthorityType(source.Type) {
-			return nil, newValidationError("source.type", fmt.Sprintf("invalid authority type for source %s: %s", source.ID, source.Type), nil)
-		}
-	}
-
-	sort.SliceStable(claims, func(i, j int) bool {
-		claimA := claims[i]
-		claimB := claims[j]
-
-		sourceA := sourcesCopy[claimA.SourceID]
-		sourceB := sourcesCopy[claimB.SourceID]
-
-		keyA := precedenceKey(sourceA, claimA, authorityOrder, artifact.Graph)
-		keyB := precedenceKey(sourceB, claimB, authorityOrder, artifact.Graph)
-
-		if cmp := comparePrecedenceKeys(keyA, keyB); cmp != 0 {
-			return cmp < 0
-		}
-		return claimA.ID < claimB.ID
-	})
-
-	topKey := precedenceKey(sourcesCopy[claims[0].SourceID], claims[0], authorityOrder, artifact.Graph)
-	samePrecedence := []Claim{}
-	for _, claim := range claims {
-		key := precedenceKey(sourcesCopy[claim.SourceID], claim, authorityOrder, artifact.Graph)
-		if comparePrecedenceKeys(topKey, key) != 0 {
-			break
-		}
-		samePrecedence = append(samePrecedence, claim)
-	}
-
-	for _, claim := range samePrecedence {
-		if claim.Type == Prohibition {
-			winner := claim
-			return &winner, nil
-		}
-	}
-
-	return &claims[0], nil
-}
-
-func (c *AuthorityCompiler) applyRevocations(artifact AuthorityArtifact) AuthorityArtifact {
-	revokedIDs := make(map[string]bool)
+// removeAmended removes the claims targeted by edges of the given type (Revokes
+// or Supersedes) and records which claims removed them.
+func (c *AuthorityCompiler) removeAmended(artifact AuthorityArtifact, edgeType EdgeType) (AuthorityArtifact, []ResolutionRecord) {
+	amendedBy := make(map[string][]string)
 	for _, edge := range artifact.Graph.Edges {
-		if edge.EdgeType == Revokes {
-			revokedIDs[edge.ToID] = true
+		if edge.EdgeType == edgeType {
+			amendedBy[edge.ToID] = append(amendedBy[edge.ToID], edge.FromID)
 		}
 	}
 
 	newClaims := []Claim{}
+	records := []ResolutionRecord{}
 	for _, claim := range artifact.Claims {
-		if !revokedIDs[claim.ID] {
+		id := claim.QualifiedID()
+		if len(amendedBy[id]) == 0 {
 			newClaims = append(newClaims, claim)
+			continue
 		}
-	}
-	artifact.Claims = newClaims
-
-	return artifact
-}
-
-func (c *AuthorityCompiler) applySupersessions(artifact AuthorityArtifact) AuthorityArtifact {
-	supersededIDs := make(map[string]bool)
-	for _, edge := range artifact.Graph.Edges {
-		if edge.EdgeType == Supersedes {
-			supersededIDs[edge.ToID] = true
-		}
-	}
-
-	newClaims := []Claim{}
-	for _, claim := range artifact.Claims {
-		if !supersededIDs[claim.ID] {
-			newClaims = append(newClaims, claim)
+		for _, by := range amendedBy[id] {
+			records = append(records, ResolutionRecord{ClaimID: id, By: by, Edge: edgeType})
 		}
 	}
 	artifact.Claims = newClaims
 
-	return artifact
+	return artifact, records
 }
 
 // Compile generates executable enforcement artifacts.
+// The artifact ID is recomputed from the resolved claims and graph, so it
+// identifies exactly what will be enforced.
 func (c *AuthorityCompiler) Compile(artifact AuthorityArtifact) AuthorityArtifact {
-	// Placeholder for compilation logic
+	// Normalize has already encoded every condition value and resolution never
+	// adds new ones, so the encoding cannot fail here.
+	if id, err := ContentID(artifact); err == nil {
+		artifact.ID = id
+	}
 	return artifact
 }
 
@@ -435,18 +415,19 @@ func (c *AuthorityCompiler) Bind(artifact AuthorityArtifact) {
 
 // Returns deterministic JSON output sorted by keys.
 func (c *AuthorityCompiler) EmitProof(artifact AuthorityArtifact) string {
-	// Build claims list deterministically (sorted by ID)
+	// Build claims list deterministically (sorted by qualified ID)
 	claimsList := make([]map[string]interface{}, 0, len(artifact.Claims))
 	sortedClaims := make([]Claim, len(artifact.Claims))
 	copy(sortedClaims, artifact.Claims)
 	sort.Slice(sortedClaims, func(i, j int) bool {
-		return sortedClaims[i].ID < sortedClaims[j].ID
+		return sortedClaims[i].QualifiedID() < sortedClaims[j].QualifiedID()
 	})
 
 	for _, claim := range sortedClaims {
 		claimsList = append(claimsList, map[string]interface{}{
 			"action":    claim.Action,
 			"id":        claim.ID,
+			"position":  claim.Position,
 			"resource":  claim.Resource,
 			"source_id": claim.SourceID,
 			"subject":   claim.Subject,
@@ -454,6 +435,46 @@ func (c *AuthorityCompiler) EmitProof(artifact AuthorityArtifact) string {
 		})
 	}
 
+	sourcesList := make([]map[string]interface{}, 0, len(artifact.Sources))
+	for _, source := range artifact.Sources {
+		sourcesList = append(sourcesList, map[string]interface{}{
+			"id":      source.ID,
+			"type":    string(source.Type),
+			"version": source.Version,
+		})
+	}
+
+	timeline := make([]map[string]interface{}, 0, len(artifact.Timeline))
+	for _, slice := range artifact.Timeline {
+		entry := map[string]interface{}{
+			"claim_id":  slice.ClaimID,
+			"origin_id": slice.OriginID,
+			"cut_by":    slice.CutBy,
+		}
+		if slice.TimeStart != nil {
+			entry["time_start"] = slice.TimeStart.Format(time.RFC3339)
+		}
+		if slice.TimeEnd != nil {
+			entry["time_end"] = slice.TimeEnd.Format(time.RFC3339)
+		}
+		timeline = append(timeline, entry)
+	}
+
+	resolutions := make([]map[string]interface{}, 0, len(artifact.Resolutions))
+	for _, record := range artifact.Resolutions {
+		entry := map[string]interface{}{
+			"by":       record.By,
+			"claim_id": record.ClaimID,
+			"edge":     string(record.Edge),
+		}
+		if record.Strategy != "" {
+			entry["strategy"] = record.Strategy
+			entry["winner_key"] = record.WinnerKey
+			entry["loser_key"] = record.LoserKey
+		}
+		resolutions = append(resolutions, entry)
+	}
+
 	proofData := map[string]interface{}{
 		"artifact_id":  artifact.ID,
 		"claims":       claimsList,
@@ -463,7 +484,11 @@ func (c *AuthorityCompiler) EmitProof(artifact AuthorityArtifact) string {
 			"edges": len(artifact.Graph.Edges),
 			"nodes": len(artifact.Graph.Nodes),
 		},
-		"source_id": artifact.SourceID,
+		"resolutions": resolutions,
+		"source_id":   artifact.SourceID,
+		"sources":     sourcesList,
+		"strategies":  artifact.Strategies,
+		"timeline":    timeline,
 	}
 
 	jsonBytes, _ := json.MarshalIndent(proofData, "", "  ")
@@ -479,14 +504,34 @@ func (c *AuthorityCompiler) Process(source AuthoritySource) interface{} {
 // ProcessWithContext runs the full compilation pipeline with context support.
 func (c *AuthorityCompiler) ProcessWithContext(ctx context.Context, source AuthoritySource) interface{} {
 	c.logger.Info("Starting compilation for source %s", source.ID)
+	return c.process(ctx, []AuthoritySource{source})
+}
 
-	artifact, err := c.Normalize(ctx, source)
+// ProcessSources runs the full compilation pipeline over a set of sources,
+// producing one artifact with precedence resolved across all of them.
+func (c *AuthorityCompiler) ProcessSources(sources []AuthoritySource) interface{} {
+	return c.ProcessSourcesWithContext(context.Background(), sources)
+}
+
+// ProcessSourcesWithContext runs the multi-source compilation pipeline with context support.
+func (c *AuthorityCompiler) ProcessSourcesWithContext(ctx context.Context, sources []AuthoritySource) interface{} {
+	c.logger.Info("Starting compilation for %d sources", len(sources))
+	return c.process(ctx, sources)
+}
+
+func (c *AuthorityCompiler) process(ctx context.Context, sources []AuthoritySource) interface{} {
+	artifact, err := c.NormalizeSources(ctx, sources)
 	if err != nil {
 		c.logger.Error("Normalization failed: %v", err)
+		involved := []string{}
+		var compErr *CompilationError
+		if errors.As(err, &compErr) && compErr.InvolvedClaimIDs != nil {
+			involved = compErr.InvolvedClaimIDs
+		}
 		return CompilationFailure{
 			FailureStage:      "normalization",
 			ViolatedInvariant: err.Error(),
-			InvolvedClaimIDs:  []string{},
+			InvolvedClaimIDs:  involved,
 			FailClosed:        true,
 		}
 	}
@@ -503,16 +548,22 @@ func (c *AuthorityCompiler) ProcessWithContext(ctx context.Context, source Autho
 	}
 	c.logger.Info("Validation passed")
 
-	artifact, err = c.ResolveConflicts(ctx, artifact)
+	resolved, err := c.ResolveConflicts(ctx, artifact)
 	if err != nil {
 		c.logger.Error("Conflict resolution failed: %v", err)
+		involved := getClaimIDs(artifact.Claims)
+		var conflictErr *ConflictError
+		if errors.As(err, &conflictErr) {
+			involved = conflictErr.ClaimIDs
+		}
 		return CompilationFailure{
 			FailureStage:      "resolution",
 			ViolatedInvariant: err.Error(),
-			InvolvedClaimIDs:  getClaimIDs(artifact.Claims),
+			InvolvedClaimIDs:  involved,
 			FailClosed:        true,
 		}
 	}
+	artifact = resolved
 	c.logger.Info("Conflict resolution complete, %d claims remaining", len(artifact.Claims))
 
 	artifact = c.Compile(artifact)
@@ -526,10 +577,27 @@ func (c *AuthorityCompiler) ProcessWithContext(ctx context.Context, source Autho
 	}
 }
 
+// sourceInfos summarizes sources for recording on an artifact, sorted by ID.
+func sourceInfos(sources []AuthoritySource) []SourceInfo {
+	infos := make([]SourceInfo, 0, len(sources))
+	for _, source := range sources {
+		infos = append(infos, SourceInfo{
+			ID:      source.ID,
+			Type:    source.Type,
+			Name:    source.Name,
+			Version: source.Version,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
 func getClaimIDs(claims []Claim) []string {
 	ids := make([]string, len(claims))
 	for i, claim := range claims {
-		ids[i] = claim.ID
+		ids[i] = claim.QualifiedID()
 	}
 	sort.Strings(ids) // Deterministic output
 	return ids
@@ -635,82 +703,55 @@ func convertMapInterface(m interface{}) map[string]interface{} {
 	if m == nil {
 		return nil
 	}
-	if mm, ok := m.(map[string]interface{}); ok {
+	if mm, ok := m.(map[string]interface{}); ok && len(mm) > 0 {
 		return mm
 	}
 	return nil
 }
 
-func generateUUID() string {
-	return uuid.New().String()
-}
-
-func precedenceKey(source AuthoritySource, claim Claim, authorityOrder map[AuthorityType]int, graph AuthorityGraph) []interface{} {
-	order := authorityOrder[source.Type]
-	version := parseVersion(source.Version)
-	versionKey := []int{-version[0], -version[1], -version[2]}
-	depth := getDelegationDepth(claim, graph)
-	specificity := getScopeSpecificity(claim.Scope)
-
-	return []interface{}{
-		order,
-		versionKey,
-		depth,
-		-specificity,
-	}
-}
-
-func comparePrecedenceKeys(a, b []interface{}) int {
-	for i := range a {
-		if i >= len(b) {
+// PrecedenceKey ranks a claim for conflict resolution; lower keys take precedence.
+// Its elements are, in order: source authority type (see AuthorityTypeOrder), the
+// negated source major, minor, and patch version, delegation depth, and negated
+// scope specificity. Later versions, shorter delegation chains, and narrower
+// scopes therefore win ties on the earlier elements.
+type PrecedenceKey []int
+
+// Compare returns -1 if k takes precedence over other, 1 if other does, and 0 if
+// they are equal.
+func (k PrecedenceKey) Compare(other PrecedenceKey) int {
+	for i := 0; i < len(k) && i < len(other); i++ {
+		if k[i] < other[i] {
+			return -1
+		} else if k[i] > other[i] {
 			return 1
 		}
-		switch av := a[i].(type) {
-		case int:
-			bv, ok := b[i].(int)
-			if !ok {
-				return -1
-			}
-			if av < bv {
-				return -1
-			} else if av > bv {
-				return 1
-			}
-		case string:
-			bv, ok := b[i].(string)
-			if !ok {
-				return 1
-			}
-			if av < bv {
-				return -1
-			} else if av > bv {
-				return 1
-			}
-		case []int:
-			bv, ok := b[i].([]int)
-			if !ok {
-				return 1
-			}
-			for j := 0; j < len(av) && j < len(bv); j++ {
-				if av[j] < bv[j] {
-					return -1
-				} else if av[j] > bv[j] {
-					return 1
-				}
-			}
-			if len(av) < len(bv) {
-				return -1
-			} else if len(av) > len(bv) {
-				return 1
-			}
-		}
 	}
-	if len(a) < len(b) {
+	if len(k) < len(other) {
 		return -1
+	} else if len(k) > len(other) {
+		return 1
 	}
 	return 0
 }
 
+// precedenceKey computes the key of a claim issued by a source of the given type
+// and version, at the given delegation depth. Unknown authority types rank below
+// every known type.
+func precedenceKey(sourceType AuthorityType, sourceVersion string, claim Claim, delegationDepth int) PrecedenceKey {
+	authorityOrder := AuthorityTypeOrder()
+	order, ok := authorityOrder[sourceType]
+	if !ok {
+		order = len(authorityOrder)
+	}
+	version := parseVersion(sourceVersion)
+	return PrecedenceKey{
+		order,
+		-version[0], -version[1], -version[2],
+		delegationDepth,
+		-getScopeSpecificity(claim.Scope),
+	}
+}
+
 func parseVersion(versionStr string) []int {
 	if versionStr == "" {
 		return []int{0, 0, 0}
@@ -734,34 +775,82 @@ func parseVersion(versionStr string) []int {
 	return []int{major, minor, patch}
 }
 
-func getDelegationDepth(claim Claim, graph AuthorityGraph) int {
-	depth := 0
-	currentID := claim.ID
-	visited := make(map[string]bool)
+// cyclicDelegationDepth is the depth given to claims on a delegation cycle, which
+// validation rejects, so that they never take precedence.
+const cyclicDelegationDepth = 999
 
-	for currentID != "" {
-		if visited[currentID] {
-			return 999 // Cycle detected (shouldn't happen if validation passed)
+// delegations indexes the Delegates edges of a graph: the delegator of each
+// delegated claim, and its delegation depth, the number of Delegates edges from
+// it up to a claim that was not delegated. Both are computed once, when the
+// index is built.
+type delegations struct {
+	parents map[string]string
+	depths  map[string]int
+}
+
+func newDelegations(graph AuthorityGraph) *delegations {
+	d := &delegations{parents: make(map[string]string), depths: make(map[string]int)}
+	for _, edge := range graph.Edges {
+		if edge.EdgeType != Delegates {
+			continue
+		}
+		if _, ok := d.parents[edge.ToID]; !ok {
+			d.parents[edge.ToID] = edge.FromID
 		}
-		visited[currentID] = true
+	}
+	for id := range d.parents {
+		d.depths[id] = d.walk(id)
+	}
+	return d
+}
 
-		// Find parent delegation
-		parent := ""
-		for _, edge := range graph.Edges {
-			if edge.ToID == currentID && edge.EdgeType == Delegates {
-				parent = edge.FromID
-				depth++
-				break
+// walk follows Delegates edges up from a claim, reusing the depths already known.
+func (d *delegations) walk(id string) int {
+	depth := 0
+	visited := make(map[string]bool)
+	for {
+		if known, ok := d.depths[id]; ok {
+			if known == cyclicDelegationDepth {
+				return known
 			}
+			return depth + known
+		}
+		if visited[id] {
+			return cyclicDelegationDepth
+		}
+		visited[id] = true
+		parent, ok := d.parents[id]
+		if !ok {
+			return depth
 		}
+		depth++
+		id = parent
+	}
+}
+
+// depth returns the delegation depth of a claim: 0 if it was not delegated.
+func (d *delegations) depth(claimID string) int {
+	return d.depths[claimID]
+}
 
-		if parent == "" {
+// path returns the claim and its delegators, walking Delegates edges up the
+// graph. It returns nil for claims that were not delegated.
+func (d *delegations) path(claimID string) []string {
+	path := []string{claimID}
+	visited := map[string]bool{claimID: true}
+	for current := claimID; ; {
+		parent, ok := d.parents[current]
+		if !ok || visited[parent] {
 			break
 		}
-		currentID = parent
+		visited[parent] = true
+		path = append(path, parent)
+		current = parent
 	}
-
-	return depth
+	if len(path) == 1 {
+		return nil
+	}
+	return path
 }
 
 func getScopeSpecificity(scope Scope) int {
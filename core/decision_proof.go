@@ -0,0 +1,171 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Decision outcomes recorded in a DecisionProof.
+const (
+	OutcomePermitted   = "permitted"
+	OutcomeProhibited  = "prohibited"
+	OutcomeNoAuthority = "no_applicable_authority"
+)
+
+// DecisionProof traces one authorization decision back to its originating authority.
+// It is derived deterministically from the artifact and the request, so any holder
+// of the artifact can re-derive and check it with VerifyDecisionProof.
+// DelegationPath lists the qualified IDs (see Claim.QualifiedID) of the deciding
+// claim and of the claims that delegated it, nearest first.
+type DecisionProof struct {
+	ArtifactID     string            `json:"artifact_id"`
+	Request        ProofRequest      `json:"request"`
+	Allowed        bool              `json:"allowed"`
+	Outcome        string            `json:"outcome"`
+	Claim          *ProofClaim       `json:"claim,omitempty"`
+	Matches        []FieldMatch      `json:"matches,omitempty"`
+	Source         *ProofSource      `json:"source,omitempty"`
+	Strategy       string            `json:"strategy,omitempty"`
+	DelegationPath []string          `json:"delegation_path,omitempty"`
+	Overridden     []OverriddenClaim `json:"overridden,omitempty"`
+}
+
+// ProofRequest records the request a decision was made for. Context is nil when
+// scope was not consulted; otherwise its Time is the instant the request was
+// evaluated at.
+type ProofRequest struct {
+	Subject  string          `json:"subject"`
+	Action   string          `json:"action"`
+	Resource string          `json:"resource"`
+	Context  *RequestContext `json:"context,omitempty"`
+}
+
+// ProofClaim identifies the claim that decided a request.
+type ProofClaim struct {
+	ID       string    `json:"id"`
+	Type     ClaimType `json:"type"`
+	Subject  string    `json:"subject"`
+	Action   string    `json:"action"`
+	Resource string    `json:"resource"`
+	SourceID string    `json:"source_id"`
+}
+
+// FieldMatch records how a claim pattern matched a request field.
+type FieldMatch struct {
+	Field   string `json:"field"`
+	Pattern string `json:"pattern"`
+	Value   string `json:"value"`
+}
+
+// ProofSource identifies the source of the deciding claim and its precedence rank
+// (lower ranks take precedence, see AuthorityTypeOrder).
+type ProofSource struct {
+	ID             string        `json:"id"`
+	Type           AuthorityType `json:"type"`
+	Version        string        `json:"version"`
+	PrecedenceRank int           `json:"precedence_rank"`
+}
+
+// OverriddenClaim records an applicable claim that did not decide the request.
+type OverriddenClaim struct {
+	ClaimID  string    `json:"claim_id"`
+	Type     ClaimType `json:"type"`
+	SourceID string    `json:"source_id"`
+	Reason   string    `json:"reason"`
+}
+
+// buildProof assembles the proof for a decision. Callers must hold ri.mu.
+func (ri *RuntimeInterface) buildProof(req ProofRequest, d decision, applicable []Claim) DecisionProof {
+	proof := DecisionProof{
+		ArtifactID: ri.artifact.ID,
+		Request:    req,
+		Allowed:    d.allowed,
+		Outcome:    OutcomeNoAuthority,
+		Strategy:   d.strategy,
+	}
+	if d.claim == nil {
+		return proof
+	}
+
+	claim := *d.claim
+	proof.Outcome = OutcomePermitted
+	if claim.Type == Prohibition {
+		proof.Outcome = OutcomeProhibited
+	}
+	proof.Claim = &ProofClaim{
+		ID:       claim.ID,
+		Type:     claim.Type,
+		Subject:  claim.Subject,
+		Action:   claim.Action,
+		Resource: claim.Resource,
+		SourceID: claim.SourceID,
+	}
+	proof.Matches = []FieldMatch{
+		{Field: "subject", Pattern: claim.Subject, Value: req.Subject},
+		{Field: "action", Pattern: claim.Action, Value: req.Action},
+		{Field: "resource", Pattern: claim.Resource, Value: req.Resource},
+	}
+	for _, source := range ri.artifact.Sources {
+		if source.ID == claim.SourceID {
+			proof.Source = &ProofSource{
+				ID:             source.ID,
+				Type:           source.Type,
+				Version:        source.Version,
+				PrecedenceRank: AuthorityTypeOrder()[source.Type],
+			}
+			break
+		}
+	}
+	proof.DelegationPath = ri.delegations.path(claim.QualifiedID())
+
+	applicableIDs := make(map[string]bool, len(applicable))
+	for _, other := range applicable {
+		applicableIDs[other.QualifiedID()] = true
+	}
+	for _, other := range applicable {
+		if other.QualifiedID() == claim.QualifiedID() || (other.Type != Permission && other.Type != Prohibition) {
+			continue
+		}
+		reason := fmt.Sprintf("%s chose %s", d.strategy, claim.QualifiedID())
+		if winner := ri.overridingClaim(other.QualifiedID(), applicableIDs); winner != "" {
+			reason = fmt.Sprintf("overridden by higher-precedence claim %s", winner)
+		}
+		proof.Overridden = append(proof.Overridden, OverriddenClaim{
+			ClaimID:  other.ID,
+			Type:     other.Type,
+			SourceID: other.SourceID,
+			Reason:   reason,
+		})
+	}
+	return proof
+}
+
+// VerifyDecisionProof re-derives the decision recorded in a proof from the artifact
+// and returns an error if the proof does not match it exactly.
+func VerifyDecisionProof(artifact AuthorityArtifact, proof DecisionProof) error {
+	if proof.ArtifactID != artifact.ID {
+		return fmt.Errorf("%w: proof is for artifact %s, not %s", ErrProofMismatch, proof.ArtifactID, artifact.ID)
+	}
+	if proof.Request.Context != nil && proof.Request.Context.Time.IsZero() {
+		return fmt.Errorf("%w: proof request context has no evaluation time", ErrProofMismatch)
+	}
+
+	ri := NewRuntimeInterface(artifact)
+	ri.mu.RLock()
+	expected := ri.evaluate(proof.Request).proof
+	ri.mu.RUnlock()
+
+	want, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+	got, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("%w: recorded decision differs from the artifact's", ErrProofMismatch)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// AlgorithmEd25519 is the Algorithm reported by Ed25519 signers.
+const AlgorithmEd25519 = "ed25519"
+
+// Ed25519Signer signs with an Ed25519 private key held in memory.
+type Ed25519Signer struct {
+	keyID string
+	key   ed25519.PrivateKey
+}
+
+// NewEd25519Signer wraps a private key. The key ID is derived from the public key
+// (see Ed25519KeyID).
+func NewEd25519Signer(key ed25519.PrivateKey) (*Ed25519Signer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("ed25519 private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(key))
+	}
+	return &Ed25519Signer{
+		keyID: Ed25519KeyID(key.Public().(ed25519.PublicKey)),
+		key:   key,
+	}, nil
+}
+
+// GenerateEd25519Signer creates a signer with a fresh random key.
+func GenerateEd25519Signer() (*Ed25519Signer, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewEd25519Signer(key)
+}
+
+// LoadEd25519Signer reads a PEM-encoded PKCS #8 Ed25519 private key file.
+func LoadEd25519Signer(path string) (*Ed25519Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("%s: no PEM PRIVATE KEY block found", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: key is %T, not an ed25519 private key", path, parsed)
+	}
+	return NewEd25519Signer(key)
+}
+
+// KeyID implements Signer.
+func (s *Ed25519Signer) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm implements Signer.
+func (s *Ed25519Signer) Algorithm() string {
+	return AlgorithmEd25519
+}
+
+// Sign implements Signer.
+func (s *Ed25519Signer) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, message), nil
+}
+
+// PublicKey returns the public half of the signing key.
+func (s *Ed25519Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// WritePrivateKey writes the private key to path as PEM-encoded PKCS #8, readable
+// only by the owner.
+func (s *Ed25519Signer) WritePrivateKey(path string) error {
+	der, err := x509.MarshalPKCS8PrivateKey(s.key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+// WritePublicKey writes the public key to path as a PEM-encoded PKIX public key.
+func (s *Ed25519Signer) WritePublicKey(path string) error {
+	der, err := x509.MarshalPKIXPublicKey(s.PublicKey())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644)
+}
+
+// Ed25519KeyID derives a stable key ID from a public key: "ed25519:" followed by
+// the first 16 hex digits of the key's SHA-256.
+func Ed25519KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return AlgorithmEd25519 + ":" + hex.EncodeToString(sum[:8])
+}
+
+// KeyRing is a Verifier trusting a fixed set of Ed25519 public keys.
+type KeyRing struct {
+	keys map[string]ed25519.PublicKey
+}
+
+// NewKeyRing creates a key ring trusting the given public keys.
+func NewKeyRing(keys ...ed25519.PublicKey) *KeyRing {
+	ring := &KeyRing{keys: make(map[string]ed25519.PublicKey)}
+	for _, key := range keys {
+		ring.Add(key)
+	}
+	return ring
+}
+
+// LoadKeyRing creates a key ring from PEM-encoded PKIX public key files. A file
+// may contain several PUBLIC KEY blocks.
+func LoadKeyRing(paths ...string) (*KeyRing, error) {
+	ring := NewKeyRing()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			if block.Type != "PUBLIC KEY" {
+				continue
+			}
+			parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			key, ok := parsed.(ed25519.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("%s: key is %T, not an ed25519 public key", path, parsed)
+			}
+			ring.Add(key)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: no PEM PUBLIC KEY block found", path)
+		}
+	}
+	return ring, nil
+}
+
+// Add trusts an additional public key.
+func (r *KeyRing) Add(key ed25519.PublicKey) {
+	r.keys[Ed25519KeyID(key)] = key
+}
+
+// Verify implements Verifier.
+func (r *KeyRing) Verify(keyID, algorithm string, message, signature []byte) error {
+	if algorithm != AlgorithmEd25519 {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, algorithm)
+	}
+	key, ok := r.keys[keyID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	if !ed25519.Verify(key, message, signature) {
+		return fmt.Errorf("%w: signature by %s does not verify", ErrInvalidSignature, keyID)
+	}
+	return nil
+}
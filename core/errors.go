@@ -14,6 +14,9 @@ var (
 	// ErrNilSource indicates an operation received a nil source.
 	ErrNilSource = errors.New("source is nil")
 
+	// ErrNoSources indicates a multi-source operation received no sources.
+	ErrNoSources = errors.New("no authority sources provided")
+
 	// ErrEmptySourceID indicates a source has an empty ID.
 	ErrEmptySourceID = errors.New("source ID is empty")
 
@@ -29,6 +32,9 @@ var (
 	// ErrUnresolvableConflict indicates conflicts could not be resolved.
 	ErrUnresolvableConflict = errors.New("unresolvable authority conflict")
 
+	// ErrAmbiguousConflict indicates equally ranked claims disagree and nothing legitimate decides between them.
+	ErrAmbiguousConflict = errors.New("ambiguous authority conflict")
+
 	// ErrInvalidScope indicates a scope failed validation.
 	ErrInvalidScope = errors.New("invalid scope")
 
@@ -38,6 +44,36 @@ var (
 	// ErrInvalidEdgeReference indicates an edge references non-existent node.
 	ErrInvalidEdgeReference = errors.New("edge references non-existent node")
 
+	// ErrUnresolvedReference indicates a claim references a claim that does not exist.
+	ErrUnresolvedReference = errors.New("unresolved claim reference")
+
+	// ErrReferenceEscalation indicates a source amends a claim of higher authority.
+	ErrReferenceEscalation = errors.New("reference targets a higher-authority source")
+
+	// ErrProofMismatch indicates a decision proof does not match its artifact.
+	ErrProofMismatch = errors.New("decision proof does not match artifact")
+
+	// ErrInvalidSignature indicates a signature is missing, malformed, or does not verify.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrUnknownKey indicates a signature was made by a key the verifier does not trust.
+	ErrUnknownKey = errors.New("unknown signing key")
+
+	// ErrUnknownStrategy indicates a conflict strategy name is not registered.
+	ErrUnknownStrategy = errors.New("unknown conflict strategy")
+
+	// ErrInvalidArtifactFile indicates a serialized artifact could not be decoded.
+	ErrInvalidArtifactFile = errors.New("invalid artifact file")
+
+	// ErrUnsupportedFormatVersion indicates a serialized artifact uses an unknown format version.
+	ErrUnsupportedFormatVersion = errors.New("unsupported artifact format version")
+
+	// ErrStaleArtifact indicates an artifact is older than the runtime accepts.
+	ErrStaleArtifact = errors.New("artifact is stale")
+
+	// ErrNoPreviousArtifact indicates a rollback was requested but no replaced artifact was kept.
+	ErrNoPreviousArtifact = errors.New("no previous artifact to roll back to")
+
 	// ErrInvalidVersion indicates a version string is malformed.
 	ErrInvalidVersion = errors.New("invalid version string")
 )
@@ -83,12 +119,20 @@ func (e *CompilationError) Unwrap() error {
 type ConflictError struct {
 	ClaimIDs []string
 	Message  string
+	Err      error
 }
 
 func (e *ConflictError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("conflict resolution failed for claims %v: %s: %v", e.ClaimIDs, e.Message, e.Err)
+	}
 	return fmt.Sprintf("conflict resolution failed for claims %v: %s", e.ClaimIDs, e.Message)
 }
 
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
 // newValidationError creates a new ValidationError.
 func newValidationError(field, message string, err error) *ValidationError {
 	return &ValidationError{Field: field, Message: message, Err: err}
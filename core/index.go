@@ -0,0 +1,198 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// claimIndex finds the claims whose subject, action, and resource patterns can
+// match a request without scanning every claim. Each field is indexed separately;
+// a lookup takes the field with the fewest candidate claims and checks the other
+// two with matchPattern. Claims are identified by their position in the artifact.
+type claimIndex struct {
+	subjects  patternIndex
+	actions   patternIndex
+	resources patternIndex
+}
+
+func newClaimIndex(claims []Claim) *claimIndex {
+	index := &claimIndex{
+		subjects:  newPatternIndex(),
+		actions:   newPatternIndex(),
+		resources: newPatternIndex(),
+	}
+	for i, claim := range claims {
+		index.subjects.add(claim.Subject, i)
+		index.actions.add(claim.Action, i)
+		index.resources.add(claim.Resource, i)
+	}
+	return index
+}
+
+// candidates returns, in ascending order, the positions of the claims that may
+// match the request. Every matching claim is included; callers must still check
+// each candidate's patterns.
+func (x *claimIndex) candidates(subject, action, resource string) []int {
+	best := x.subjects.lookup(subject)
+	for _, postings := range [][][]int{x.actions.lookup(action), x.resources.lookup(resource)} {
+		if postingsLen(postings) < postingsLen(best) {
+			best = postings
+		}
+	}
+
+	positions := make([]int, 0, postingsLen(best))
+	for _, posting := range best {
+		positions = append(positions, posting...)
+	}
+	if len(best) > 1 {
+		sort.Ints(positions)
+	}
+	return positions
+}
+
+// newOverlapIndex indexes claims for overlapping. The runtime only needs
+// candidates, so it builds its index with newClaimIndex instead.
+func newOverlapIndex(claims []Claim) *claimIndex {
+	index := newClaimIndex(claims)
+	index.subjects.sortExact()
+	index.actions.sortExact()
+	index.resources.sortExact()
+	return index
+}
+
+// overlapping returns, in ascending order, the positions of the claims whose
+// patterns may overlap those of claim (see claimsOverlap). Every overlapping claim
+// is included, claim itself among them; callers must still check each one. The
+// index must have been built with newOverlapIndex.
+func (x *claimIndex) overlapping(claim Claim) []int {
+	best := x.subjects.overlapping(claim.Subject)
+	for _, postings := range [][][]int{x.actions.overlapping(claim.Action), x.resources.overlapping(claim.Resource)} {
+		if postingsLen(postings) < postingsLen(best) {
+			best = postings
+		}
+	}
+
+	positions := make([]int, 0, postingsLen(best))
+	for _, posting := range best {
+		positions = append(positions, posting...)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+func postingsLen(postings [][]int) int {
+	n := 0
+	for _, posting := range postings {
+		n += len(posting)
+	}
+	return n
+}
+
+// patternIndex indexes one claim field under the matching rules of matchPattern:
+// a pattern ending in "*" matches every value with the preceding prefix, and any
+// other pattern matches only itself. Exact patterns live in a map and prefix
+// patterns in a trie, so a lookup costs one map probe plus one walk along the value.
+type patternIndex struct {
+	exact    map[string][]int
+	prefixes *trieNode
+	sorted   []string // the exact patterns, sorted; only set by sortExact
+}
+
+type trieNode struct {
+	children map[byte]*trieNode
+	claims   []int // claims whose prefix pattern ends at this node
+}
+
+func newPatternIndex() patternIndex {
+	return patternIndex{exact: make(map[string][]int), prefixes: &trieNode{}}
+}
+
+func (p patternIndex) add(pattern string, position int) {
+	parsed := parsePattern(pattern)
+	if !parsed.prefix {
+		p.exact[parsed.value] = append(p.exact[parsed.value], position)
+		return
+	}
+	node := p.prefixes
+	for i := 0; i < len(parsed.value); i++ {
+		child, ok := node.children[parsed.value[i]]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[byte]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[parsed.value[i]] = child
+		}
+		node = child
+	}
+	node.claims = append(node.claims, position)
+}
+
+// lookup returns the postings of every pattern matching value. A claim appears in
+// at most one posting, since each claim has one pattern per field.
+func (p patternIndex) lookup(value string) [][]int {
+	postings := [][]int{}
+	if exact := p.exact[value]; len(exact) > 0 {
+		postings = append(postings, exact)
+	}
+	node := p.prefixes
+	for i := 0; ; i++ {
+		if len(node.claims) > 0 {
+			postings = append(postings, node.claims)
+		}
+		if i == len(value) {
+			break
+		}
+		if node = node.children[value[i]]; node == nil {
+			break
+		}
+	}
+	return postings
+}
+
+// sortExact records the exact patterns in sorted order, which overlapping needs
+// to find those starting with a prefix.
+func (p *patternIndex) sortExact() {
+	p.sorted = make([]string, 0, len(p.exact))
+	for value := range p.exact {
+		p.sorted = append(p.sorted, value)
+	}
+	sort.Strings(p.sorted)
+}
+
+// overlapping returns the postings of every pattern that overlaps pattern under
+// claimPattern.overlaps. An exact pattern overlaps the patterns matching its
+// value; a prefix pattern overlaps the prefix patterns along its path in the trie,
+// every pattern below it, and the exact patterns starting with its prefix.
+func (p patternIndex) overlapping(pattern string) [][]int {
+	parsed := parsePattern(pattern)
+	if !parsed.prefix {
+		return p.lookup(parsed.value)
+	}
+	postings := [][]int{}
+	node := p.prefixes
+	for i := 0; i < len(parsed.value) && node != nil; i++ {
+		if len(node.claims) > 0 {
+			postings = append(postings, node.claims)
+		}
+		node = node.children[parsed.value[i]]
+	}
+	if node != nil {
+		postings = node.collect(postings)
+	}
+	for i := sort.SearchStrings(p.sorted, parsed.value); i < len(p.sorted) && strings.HasPrefix(p.sorted[i], parsed.value); i++ {
+		postings = append(postings, p.exact[p.sorted[i]])
+	}
+	return postings
+}
+
+// collect appends the claims of the node and of every node below it.
+func (n *trieNode) collect(postings [][]int) [][]int {
+	if len(n.claims) > 0 {
+		postings = append(postings, n.claims)
+	}
+	for _, child := range n.children {
+		postings = child.collect(postings)
+	}
+	return postings
+}
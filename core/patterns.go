@@ -0,0 +1,94 @@
+package core
+
+import "strings"
+
+// claimPattern is the set of values a claim subject, action, or resource pattern
+// matches under matchPattern: either exactly one value, or every value with a
+// given prefix. "*" is the prefix pattern with an empty prefix, and "/repos/*" is
+// the prefix pattern "/repos/".
+type claimPattern struct {
+	prefix bool
+	value  string // the exact value, or the prefix
+}
+
+func parsePattern(pattern string) claimPattern {
+	if strings.HasSuffix(pattern, "*") {
+		return claimPattern{prefix: true, value: strings.TrimSuffix(pattern, "*")}
+	}
+	return claimPattern{value: pattern}
+}
+
+// overlaps reports whether some value is matched by both patterns.
+func (p claimPattern) overlaps(q claimPattern) bool {
+	switch {
+	case p.prefix && q.prefix:
+		return strings.HasPrefix(p.value, q.value) || strings.HasPrefix(q.value, p.value)
+	case p.prefix:
+		return strings.HasPrefix(q.value, p.value)
+	case q.prefix:
+		return strings.HasPrefix(p.value, q.value)
+	default:
+		return p.value == q.value
+	}
+}
+
+// covers reports whether every value matched by q is also matched by p.
+func (p claimPattern) covers(q claimPattern) bool {
+	if p.prefix {
+		return strings.HasPrefix(q.value, p.value)
+	}
+	return !q.prefix && p.value == q.value
+}
+
+// claimsOverlap reports whether some request is matched by both claims and lies
+// within both of their scopes.
+func claimsOverlap(a, b Claim) bool {
+	return scopesOverlap(a.Scope, b.Scope) &&
+		parsePattern(a.Subject).overlaps(parsePattern(b.Subject)) &&
+		parsePattern(a.Action).overlaps(parsePattern(b.Action)) &&
+		parsePattern(a.Resource).overlaps(parsePattern(b.Resource))
+}
+
+// scopesOverlap reports whether two scopes share a jurisdiction, an operation, and
+// an instant. Empty sets and missing time bounds are unbounded.
+func scopesOverlap(a, b Scope) bool {
+	if !stringSetsIntersect(a.Jurisdictions, b.Jurisdictions) ||
+		!stringSetsIntersect(a.Operations, b.Operations) {
+		return false
+	}
+	// Time windows are half-open: [TimeStart, TimeEnd)
+	if a.TimeStart != nil && b.TimeEnd != nil && !a.TimeStart.Before(*b.TimeEnd) {
+		return false
+	}
+	if b.TimeStart != nil && a.TimeEnd != nil && !b.TimeStart.Before(*a.TimeEnd) {
+		return false
+	}
+	return true
+}
+
+func stringSetsIntersect(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	set := make(map[string]bool, len(a))
+	for _, value := range a {
+		set[value] = true
+	}
+	for _, value := range b {
+		if set[value] {
+			return true
+		}
+	}
+	return false
+}
+
+// claimCovers reports whether outer matches every request inner matches while
+// both are in force: outer's patterns cover inner's, and inner's jurisdictions and
+// operations are contained in outer's. Time windows are not compared.
+func claimCovers(outer, inner Claim) bool {
+	return isStringSetContained(inner.Scope.Jurisdictions, outer.Scope.Jurisdictions) &&
+		isStringSetContained(inner.Scope.Operations, outer.Scope.Operations) &&
+		parsePattern(outer.Subject).covers(parsePattern(inner.Subject)) &&
+		parsePattern(outer.Action).covers(parsePattern(inner.Action)) &&
+		parsePattern(outer.Resource).covers(parsePattern(inner.Resource))
+}
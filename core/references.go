@@ -0,0 +1,231 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// claimRelations maps the claim condition keys that reference other claims to the
+// graph edge each reference produces.
+var claimRelations = []struct {
+	Key      string
+	EdgeType EdgeType
+}{
+	{Key: "delegates_to", EdgeType: Delegates},
+	{Key: "revokes", EdgeType: Revokes},
+	{Key: "supersedes", EdgeType: Supersedes},
+}
+
+func (t EdgeType) verb() string {
+	switch t {
+	case Revokes:
+		return "revoke"
+	case Supersedes:
+		return "supersede"
+	default:
+		return "delegate to"
+	}
+}
+
+// ClaimRef identifies a claim, optionally qualified by the source that declared it.
+// An unqualified reference (empty SourceID) resolves within the referencing claim's
+// own source; a qualified one lets a source amend claims issued by another source,
+// and picks out the right one when several sources declare the same claim ID.
+type ClaimRef struct {
+	SourceID string `json:"source_id,omitempty"`
+	ClaimID  string `json:"claim_id"`
+}
+
+// String renders the reference in its textual form, "source_id:claim_id" or "claim_id".
+func (r ClaimRef) String() string {
+	if r.SourceID == "" {
+		return r.ClaimID
+	}
+	return r.SourceID + ":" + r.ClaimID
+}
+
+// ParseClaimRef parses a claim reference from a claim condition value.
+// Accepted forms are a string "claim_id", a qualified string "source_id:claim_id",
+// or a map with "source_id" and "claim_id" keys. Claim IDs cannot contain ':'
+// (see ParseClaim), so a qualified string is split at its last ':'.
+func ParseClaimRef(value interface{}) (ClaimRef, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return ClaimRef{}, newValidationError("claim_ref", "claim reference is empty", ErrUnresolvedReference)
+		}
+		if i := strings.LastIndex(v, ":"); i >= 0 {
+			sourceID, claimID := v[:i], v[i+1:]
+			if sourceID == "" || claimID == "" {
+				return ClaimRef{}, newValidationError("claim_ref", fmt.Sprintf("malformed qualified reference: %s", v), ErrUnresolvedReference)
+			}
+			return ClaimRef{SourceID: sourceID, ClaimID: claimID}, nil
+		}
+		return ClaimRef{ClaimID: v}, nil
+	case map[string]interface{}:
+		claimID, _ := v["claim_id"].(string)
+		if claimID == "" {
+			return ClaimRef{}, newValidationError("claim_ref.claim_id", "claim reference requires claim_id", ErrUnresolvedReference)
+		}
+		sourceID, _ := v["source_id"].(string)
+		return ClaimRef{SourceID: sourceID, ClaimID: claimID}, nil
+	default:
+		return ClaimRef{}, newValidationError("claim_ref", fmt.Sprintf("unsupported claim reference type %T", value), ErrUnresolvedReference)
+	}
+}
+
+// parseClaimRefs parses a condition value holding one reference or a list of them.
+func parseClaimRefs(value interface{}) ([]ClaimRef, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		if list, isStrings := value.([]string); isStrings {
+			items = make([]interface{}, len(list))
+			for i, s := range list {
+				items[i] = s
+			}
+		} else {
+			items = []interface{}{value}
+		}
+	}
+
+	refs := make([]ClaimRef, 0, len(items))
+	for _, item := range items {
+		ref, err := ParseClaimRef(item)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// claimRefIndex resolves claim references against a set of claims.
+type claimRefIndex struct {
+	byID map[string][]Claim // qualified claim ID -> the claim, or its slices
+}
+
+// newClaimRefIndex indexes claims by qualified ID. origins maps the qualified IDs
+// of time slices to that of the claim they were cut from (see TimeSlice), so
+// references to a sliced claim resolve to every one of its slices; it may be nil.
+func newClaimRefIndex(claims []Claim, origins map[string]string) claimRefIndex {
+	index := claimRefIndex{byID: make(map[string][]Claim)}
+	for _, claim := range claims {
+		id := claim.QualifiedID()
+		if origin, ok := origins[id]; ok {
+			id = origin
+		}
+		index.byID[id] = append(index.byID[id], claim)
+	}
+	return index
+}
+
+// resolve returns the claims a reference made by from points to: one claim, or
+// the slices of a claim that conflict resolution cut in time. An unqualified
+// reference resolves within the source of from, so sources may reuse claim IDs.
+func (idx claimRefIndex) resolve(from Claim, ref ClaimRef) ([]Claim, bool) {
+	if ref.SourceID == "" {
+		ref.SourceID = from.SourceID
+	}
+	targets, ok := idx.byID[ref.String()]
+	return targets, ok
+}
+
+// claimEdges returns the graph edges produced by a claim's references, skipping any
+// that do not resolve against the index.
+func (idx claimRefIndex) claimEdges(claim Claim) []Edge {
+	edges := []Edge{}
+	if claim.Conditions == nil {
+		return edges
+	}
+	for _, relation := range claimRelations {
+		value, ok := claim.Conditions[relation.Key]
+		if !ok {
+			continue
+		}
+		refs, err := parseClaimRefs(value)
+		if err != nil {
+			continue
+		}
+		for _, ref := range refs {
+			targets, _ := idx.resolve(claim, ref)
+			for _, target := range targets {
+				edges = append(edges, Edge{
+					FromID:   claim.QualifiedID(),
+					ToID:     target.QualifiedID(),
+					EdgeType: relation.EdgeType,
+				})
+			}
+		}
+	}
+	return edges
+}
+
+// checkClaimReferences fails closed if any claim references a claim that does not
+// exist in the referenced source. Silently dropping such a reference would leave a
+// revocation or supersession unapplied. A source may only revoke or supersede claims
+// of a source with equal or lower authority, so amendments cannot escalate.
+func checkClaimReferences(claims []Claim, sourceTypes map[string]AuthorityType) error {
+	index := newClaimRefIndex(claims, nil)
+	authorityOrder := AuthorityTypeOrder()
+	var unresolved []string
+	involved := make(map[string]bool)
+
+	for _, claim := range claims {
+		if claim.Conditions == nil {
+			continue
+		}
+		for _, relation := range claimRelations {
+			value, ok := claim.Conditions[relation.Key]
+			if !ok {
+				continue
+			}
+			refs, err := parseClaimRefs(value)
+			if err != nil {
+				return &CompilationError{
+					Stage:            "normalization",
+					Message:          fmt.Sprintf("claim %s has malformed %s reference", claim.QualifiedID(), relation.Key),
+					InvolvedClaimIDs: []string{claim.QualifiedID()},
+					Err:              err,
+				}
+			}
+			for _, ref := range refs {
+				targets, ok := index.resolve(claim, ref)
+				if !ok {
+					unresolved = append(unresolved, fmt.Sprintf("%s %s %s", claim.QualifiedID(), relation.Key, ref))
+					involved[claim.QualifiedID()] = true
+					continue
+				}
+				target := targets[0]
+				if relation.EdgeType == Delegates || target.SourceID == claim.SourceID {
+					continue
+				}
+				if authorityOrder[sourceTypes[claim.SourceID]] > authorityOrder[sourceTypes[target.SourceID]] {
+					return &CompilationError{
+						Stage: "normalization",
+						Message: fmt.Sprintf("claim %s from %s source %s cannot %s claim %s from %s source %s",
+							claim.ID, sourceTypes[claim.SourceID], claim.SourceID, relation.EdgeType.verb(),
+							target.ID, sourceTypes[target.SourceID], target.SourceID),
+						InvolvedClaimIDs: []string{claim.QualifiedID(), target.QualifiedID()},
+						Err:              ErrReferenceEscalation,
+					}
+				}
+			}
+		}
+	}
+
+	if len(unresolved) == 0 {
+		return nil
+	}
+	claimIDs := make([]string, 0, len(involved))
+	for id := range involved {
+		claimIDs = append(claimIDs, id)
+	}
+	sort.Strings(claimIDs)
+	return &CompilationError{
+		Stage:            "normalization",
+		Message:          fmt.Sprintf("unresolved claim references: %s", strings.Join(unresolved, ", ")),
+		InvolvedClaimIDs: claimIDs,
+		Err:              ErrUnresolvedReference,
+	}
+}
@@ -0,0 +1,361 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimeSlice records a claim whose time window conflict resolution narrowed. Where
+// a higher-precedence claim takes over part of a claim's window, only the rest of
+// the window stays in force; if that rest is split in two, each part becomes its
+// own claim with ID "<origin>#<n>". ClaimID, OriginID, and CutBy hold qualified
+// claim IDs (see Claim.QualifiedID).
+type TimeSlice struct {
+	ClaimID   string     `json:"claim_id"`
+	OriginID  string     `json:"origin_id"`
+	TimeStart *time.Time `json:"time_start,omitempty"`
+	TimeEnd   *time.Time `json:"time_end,omitempty"`
+	CutBy     []string   `json:"cut_by"`
+}
+
+// ResolutionRecord records a claim that conflict resolution removed from an
+// artifact, so that its absence can be explained. Edge is the relation by which By
+// removed ClaimID: Revokes or Supersedes for amendments declared in sources, or
+// Overrides for a claim that lost a conflict to By everywhere it could apply. For
+// Overrides, Strategy names the conflict strategy that decided, and WinnerKey and
+// LoserKey are the precedence keys it was given. ClaimID and By are qualified
+// claim IDs (see Claim.QualifiedID).
+type ResolutionRecord struct {
+	ClaimID   string        `json:"claim_id"`
+	By        string        `json:"by"`
+	Edge      EdgeType      `json:"edge"`
+	Strategy  string        `json:"strategy,omitempty"`
+	WinnerKey PrecedenceKey `json:"winner_key,omitempty"`
+	LoserKey  PrecedenceKey `json:"loser_key,omitempty"`
+}
+
+// sortResolutions orders records by removed claim, then remover, then edge type.
+func sortResolutions(records []ResolutionRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].ClaimID != records[j].ClaimID {
+			return records[i].ClaimID < records[j].ClaimID
+		}
+		if records[i].By != records[j].By {
+			return records[i].By < records[j].By
+		}
+		return records[i].Edge < records[j].Edge
+	})
+}
+
+// window is a half-open time interval [start, end); nil bounds are unbounded.
+type window struct {
+	start *time.Time
+	end   *time.Time
+}
+
+func scopeWindow(scope Scope) window {
+	return window{start: scope.TimeStart, end: scope.TimeEnd}
+}
+
+// subtract returns the parts of w outside cut, in time order.
+func (w window) subtract(cut window) []window {
+	pieces := []window{}
+	if cut.start != nil {
+		left := window{start: w.start, end: earliest(w.end, cut.start)}
+		if left.nonEmpty() {
+			pieces = append(pieces, left)
+		}
+	}
+	if cut.end != nil {
+		right := window{start: latest(w.start, cut.end), end: w.end}
+		if right.nonEmpty() {
+			pieces = append(pieces, right)
+		}
+	}
+	return pieces
+}
+
+func (w window) nonEmpty() bool {
+	return w.start == nil || w.end == nil || w.start.Before(*w.end)
+}
+
+// earliest returns the earlier of two end bounds, where nil is +infinity.
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+// latest returns the later of two start bounds, where nil is -infinity.
+func latest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
+
+// timeCut is part of a claim's window taken over by a higher-precedence claim,
+// with the decision that gave it to that claim.
+type timeCut struct {
+	window window
+	by     string
+	record ResolutionRecord
+}
+
+// overlapResolution is the outcome of resolving overlapping claims.
+type overlapResolution struct {
+	candidates map[string]Claim // keyed by qualified claim ID, as are removed and slices
+	removed    map[string]bool
+	slices     map[string][]Claim // origin claim -> claims replacing it
+	overrides  []Edge
+	timeline   []TimeSlice
+	records    []ResolutionRecord // claims removed because they lost everywhere
+}
+
+// resolveOverlaps applies the bound ConflictStrategy to every pair of permissions
+// and prohibitions that match a common request, using the wildcard semantics of
+// matchPattern. Pairs are found through a claim index, so claims whose patterns
+// cannot overlap are never compared.
+// Claims whose scopes are disjoint never meet at runtime and both stay in force.
+// Where the winner covers the loser's patterns, jurisdictions, and operations, the
+// loser can never decide a request while the winner is in force, so the winner's
+// time window is cut out of the loser's; a loser left with no time is removed.
+// Otherwise both are kept and an Overrides edge from winner to loser tells the
+// runtime which one decides inside the overlap.
+func (c *AuthorityCompiler) resolveOverlaps(artifact AuthorityArtifact) (*overlapResolution, error) {
+	candidates := []Claim{}
+	for _, claim := range artifact.Claims {
+		// Delegation and obligation don't conflict with permissions/prohibitions
+		if claim.Type == Permission || claim.Type == Prohibition {
+			candidates = append(candidates, claim)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].QualifiedID() < candidates[j].QualifiedID()
+	})
+
+	rank := newRanker(artifact, newDelegations(artifact.Graph), true)
+	selector := newStrategySelector(artifact)
+	index := newOverlapIndex(candidates)
+	cuts := make(map[string][]timeCut)
+	overrides := []Edge{}
+	for i, a := range candidates {
+		for _, j := range index.overlapping(a) {
+			if j <= i {
+				continue
+			}
+			b := candidates[j]
+			if !claimsOverlap(a, b) {
+				continue
+			}
+			pair := []Claim{a, b}
+			ranked, err := rank.candidates(pair)
+			if err != nil {
+				return nil, err
+			}
+			strategy, err := selector.forClaims(pair)
+			if err != nil {
+				return nil, &ConflictError{ClaimIDs: []string{a.QualifiedID(), b.QualifiedID()}, Message: "no conflict strategy applies", Err: err}
+			}
+			chosen, err := resolveUnambiguously(strategy, ranked)
+			if err != nil {
+				return nil, &ConflictError{
+					ClaimIDs: []string{a.QualifiedID(), b.QualifiedID()},
+					Message:  fmt.Sprintf("unresolvable conflict under %s - failing closed", strategy.Name()),
+					Err:      err,
+				}
+			}
+			winner, loser := chosen, ranked[0]
+			winnerID, loserID := winner.Claim.QualifiedID(), loser.Claim.QualifiedID()
+			if winnerID == loserID {
+				loser = ranked[1]
+				loserID = loser.Claim.QualifiedID()
+			}
+			if claimCovers(winner.Claim, loser.Claim) {
+				cuts[loserID] = append(cuts[loserID], timeCut{
+					window: scopeWindow(winner.Claim.Scope),
+					by:     winnerID,
+					record: ResolutionRecord{
+						ClaimID:   loserID,
+						By:        winnerID,
+						Edge:      Overrides,
+						Strategy:  strategy.Name(),
+						WinnerKey: winner.Precedence,
+						LoserKey:  loser.Precedence,
+					},
+				})
+			} else if winner.Claim.Type != loser.Claim.Type {
+				overrides = append(overrides, Edge{FromID: winnerID, ToID: loserID, EdgeType: Overrides})
+			}
+		}
+	}
+
+	resolution := &overlapResolution{
+		candidates: make(map[string]Claim, len(candidates)),
+		removed:    make(map[string]bool),
+		slices:     make(map[string][]Claim),
+		overrides:  overrides,
+		timeline:   []TimeSlice{},
+		records:    []ResolutionRecord{},
+	}
+	for _, claim := range candidates {
+		id := claim.QualifiedID()
+		resolution.candidates[id] = claim
+		if len(cuts[id]) > 0 {
+			resolution.slice(claim, cuts[id])
+		}
+	}
+	return resolution, nil
+}
+
+// resolveUnambiguously resolves a pair of ranked candidates and checks that the
+// outcome does not hinge on how equal candidates were ordered. Candidates with
+// the same precedence key are only ordered by type and ID; if the strategy picks
+// a different claim when they are swapped and the two are not equivalent, that
+// is, they differ in effect or in conditions, the conflict is ambiguous and fails
+// closed. A strategy that decides by effect or by declaration order, such as
+// deny-overrides or first-applicable, is not affected by the swap.
+func resolveUnambiguously(strategy ConflictStrategy, ranked []Candidate) (Candidate, error) {
+	chosen, err := strategy.Resolve(ranked)
+	if err != nil {
+		return Candidate{}, err
+	}
+	first, second := ranked[0], ranked[1]
+	if first.Precedence.Compare(second.Precedence) != 0 {
+		return chosen, nil
+	}
+	alternative, err := strategy.Resolve([]Candidate{second, first})
+	if err != nil {
+		return Candidate{}, err
+	}
+	if alternative.Claim.QualifiedID() == chosen.Claim.QualifiedID() || equivalentClaims(alternative.Claim, chosen.Claim) {
+		return chosen, nil
+	}
+	if first.Claim.Type != second.Claim.Type {
+		return Candidate{}, fmt.Errorf("%w: %s %s and %s %s have precedence key %v but opposite effects",
+			ErrAmbiguousConflict, first.Claim.Type, first.Claim.QualifiedID(), second.Claim.Type, second.Claim.QualifiedID(), first.Precedence)
+	}
+	return Candidate{}, fmt.Errorf("%w: %s %s and %s have precedence key %v but different conditions",
+		ErrAmbiguousConflict, first.Claim.Type, first.Claim.QualifiedID(), second.Claim.QualifiedID(), first.Precedence)
+}
+
+// equivalentClaims reports whether two claims grant the same thing wherever both
+// apply, that is, whether they have the same type and equal conditions.
+func equivalentClaims(a, b Claim) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	if len(a.Conditions) == 0 && len(b.Conditions) == 0 {
+		return true
+	}
+	encodedA, errA := json.Marshal(a.Conditions)
+	encodedB, errB := json.Marshal(b.Conditions)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// slice cuts the given windows out of a claim's time window.
+func (r *overlapResolution) slice(claim Claim, cuts []timeCut) {
+	pieces := []window{scopeWindow(claim.Scope)}
+	cutBy := []string{}
+	for _, cut := range cuts {
+		next := []window{}
+		for _, piece := range pieces {
+			next = append(next, piece.subtract(cut.window)...)
+		}
+		pieces = next
+		cutBy = append(cutBy, cut.by)
+	}
+	sort.Strings(cutBy)
+
+	id := claim.QualifiedID()
+	if len(pieces) == 0 {
+		r.removed[id] = true
+		for _, cut := range cuts {
+			r.records = append(r.records, cut.record)
+		}
+		return
+	}
+
+	slices := make([]Claim, len(pieces))
+	for i, piece := range pieces {
+		slice := claim
+		if len(pieces) > 1 {
+			slice.ID = fmt.Sprintf("%s#%d", claim.ID, i+1)
+		}
+		slice.Scope.TimeStart = copyTime(piece.start)
+		slice.Scope.TimeEnd = copyTime(piece.end)
+		slices[i] = slice
+		r.timeline = append(r.timeline, TimeSlice{
+			ClaimID:   slice.QualifiedID(),
+			OriginID:  id,
+			TimeStart: slice.Scope.TimeStart,
+			TimeEnd:   slice.Scope.TimeEnd,
+			CutBy:     cutBy,
+		})
+	}
+	r.slices[id] = slices
+}
+
+// claims applies the resolution to a claim list, preserving its order.
+func (r *overlapResolution) claims(claims []Claim) []Claim {
+	resolved := []Claim{}
+	for _, claim := range claims {
+		if r.removed[claim.QualifiedID()] {
+			continue
+		}
+		if slices, ok := r.slices[claim.QualifiedID()]; ok {
+			resolved = append(resolved, slices...)
+			continue
+		}
+		resolved = append(resolved, claim)
+	}
+	return resolved
+}
+
+// overrideEdges returns the Overrides edges between surviving claims. Edges to or
+// from a sliced claim are carried over to each slice still overlapping the other end.
+func (r *overlapResolution) overrideEdges() []Edge {
+	edges := []Edge{}
+	for _, edge := range r.overrides {
+		if r.removed[edge.FromID] || r.removed[edge.ToID] {
+			continue
+		}
+		for _, from := range r.current(edge.FromID) {
+			for _, to := range r.current(edge.ToID) {
+				if scopesOverlap(from.Scope, to.Scope) {
+					edges = append(edges, Edge{FromID: from.QualifiedID(), ToID: to.QualifiedID(), EdgeType: Overrides})
+				}
+			}
+		}
+	}
+	return edges
+}
+
+// origins maps the qualified ID of every slice to that of the claim it was cut from.
+func (r *overlapResolution) origins() map[string]string {
+	origins := make(map[string]string, len(r.timeline))
+	for _, slice := range r.timeline {
+		origins[slice.ClaimID] = slice.OriginID
+	}
+	return origins
+}
+
+// current returns what a candidate claim became after slicing.
+func (r *overlapResolution) current(id string) []Claim {
+	if slices, ok := r.slices[id]; ok {
+		return slices
+	}
+	return []Claim{r.candidates[id]}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
@@ -1,141 +1,574 @@
 package core
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 	"time"
 )
 
+// AuthorizationRequest is a query against compiled authority. When Context is
+// nil, claim scope is not consulted; otherwise claims whose scope does not cover
+// the context are not applicable, as described on RequestContext.
+type AuthorizationRequest struct {
+	Subject  string          `json:"subject"`
+	Action   string          `json:"action"`
+	Resource string          `json:"resource"`
+	Context  *RequestContext `json:"context,omitempty"`
+}
+
 // AuthorizationResult represents the result of an authorization query.
+// AuthorityID is the deciding claim, or the artifact ID when failing closed, in
+// which case Scope is empty. Generation identifies the artifact that was enforced
+// (see RuntimeInterface.Swap).
 type AuthorizationResult struct {
-	Allowed     bool                   `json:"allowed"`
-	AuthorityID string                 `json:"authority_id"`
-	Reason      string                 `json:"reason"`
-	Scope       map[string]interface{} `json:"scope"`
+	Allowed     bool          `json:"allowed"`
+	AuthorityID string        `json:"authority_id"`
+	Reason      string        `json:"reason"`
+	Scope       Scope         `json:"scope"`
+	Generation  uint64        `json:"generation"`
+	Proof       DecisionProof `json:"proof"`
+}
+
+// AuthorityInfo describes the claims that apply to a request.
+type AuthorityInfo struct {
+	ArtifactID       string  `json:"artifact_id"`
+	ApplicableClaims []Claim `json:"applicable_claims"`
+	TotalClaims      int     `json:"total_claims"`
 }
 
 // RuntimeInterface defines how runtime systems query ARE for authorization decisions.
 // Thread-safe for concurrent authorization queries.
+// The enforced artifact can be replaced while queries are running with Swap; each
+// query is decided entirely under one artifact.
 // Note: RuntimeInterface responses are advisory reflections of compiled authority.
 // Runtime systems MUST enforce constraints independently.
 type RuntimeInterface struct {
-	artifact AuthorityArtifact
-	mu       sync.RWMutex
+	*runtimeState
+	previous     *runtimeState // artifact replaced by the last Swap, if kept
+	keepPrevious bool
+	checks       artifactChecks // applied to every swapped-in artifact; fixed at creation
+	changed      chan struct{}  // closed and replaced whenever the artifact is replaced
+	mu           sync.RWMutex
+}
+
+// runtimeState is an enforced artifact with the lookup structures derived from it.
+type runtimeState struct {
+	artifact     AuthorityArtifact
+	generation   uint64
+	overriddenBy map[string][]string // qualified claim ID -> those of the claims overriding it
+	delegations  *delegations        // delegators and delegation depths, for ranking and proofs
+	index        *claimIndex
+	rank         *ranker
+	strategies   *strategySelector
 }
 
 // NewRuntimeInterface creates a new thread-safe instance of RuntimeInterface.
+// The artifact is enforced as generation 1. It is not validated; use
+// NewValidatedRuntimeInterface for artifacts that did not come straight from the
+// compiler.
 func NewRuntimeInterface(artifact AuthorityArtifact) *RuntimeInterface {
-	return &RuntimeInterface{
-		artifact: artifact,
+	return &RuntimeInterface{runtimeState: newRuntimeState(artifact, 1), changed: make(chan struct{})}
+}
+
+func newRuntimeState(artifact AuthorityArtifact, generation uint64) *runtimeState {
+	overriddenBy := make(map[string][]string)
+	for _, edge := range artifact.Graph.Edges {
+		if edge.EdgeType == Overrides {
+			overriddenBy[edge.ToID] = append(overriddenBy[edge.ToID], edge.FromID)
+		}
+	}
+	delegations := newDelegations(artifact.Graph)
+	return &runtimeState{
+		artifact:     artifact,
+		generation:   generation,
+		overriddenBy: overriddenBy,
+		delegations:  delegations,
+		index:        newClaimIndex(artifact.Claims),
+		rank:         newRanker(artifact, delegations, false),
+		strategies:   newStrategySelector(artifact),
 	}
 }
 
-// IsAuthorized checks if an action is authorized under the given authority.
+// Swap validates an artifact with ValidateAirWithErrors and, if it is valid,
+// atomically makes it the enforced artifact under the next generation number,
+// which it returns. Queries already running finish under the old artifact. An
+// invalid artifact is rejected and the current one stays in force.
+// The checks the runtime was created with (see NewValidatedRuntimeInterface) are
+// applied as well; a runtime that requires signatures rejects every artifact
+// passed to Swap, and must be given them with SwapVerified.
 // Thread-safe for concurrent access.
-func (ri *RuntimeInterface) IsAuthorized(subject, action, resource string) map[string]interface{} {
+func (ri *RuntimeInterface) Swap(artifact AuthorityArtifact) (uint64, error) {
+	return ri.swap(artifact, nil)
+}
+
+// SwapVerified is Swap for a signed artifact: sig is checked against the verifier
+// the runtime was created with (see WithSignature).
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) SwapVerified(artifact AuthorityArtifact, sig Signature) (uint64, error) {
+	return ri.swap(artifact, &sig)
+}
+
+func (ri *RuntimeInterface) swap(artifact AuthorityArtifact, sig *Signature) (uint64, error) {
+	if err := ri.checks.check(artifact, sig); err != nil {
+		return 0, err
+	}
+	// Build the lookup structures before taking the lock so queries are not
+	// blocked while a large artifact is indexed.
+	next := newRuntimeState(artifact, 0)
+
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	next.generation = ri.generation + 1
+	if ri.keepPrevious {
+		ri.previous = ri.runtimeState
+	}
+	ri.runtimeState = next
+	ri.notifyChanged()
+	return next.generation, nil
+}
+
+// Rollback restores the artifact replaced by the last Swap, under the next
+// generation number, which it returns. It fails with ErrNoPreviousArtifact unless
+// SetKeepPrevious is enabled and a Swap has happened since the last Rollback.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) Rollback() (uint64, error) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if ri.previous == nil {
+		return 0, ErrNoPreviousArtifact
+	}
+	restored := *ri.previous
+	restored.generation = ri.generation + 1
+	ri.runtimeState = &restored
+	ri.previous = nil
+	ri.notifyChanged()
+	return restored.generation, nil
+}
+
+// Changed returns a channel that is closed the next time Swap or Rollback
+// replaces the enforced artifact, for callers that follow generation changes.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) Changed() <-chan struct{} {
 	ri.mu.RLock()
 	defer ri.mu.RUnlock()
+	return ri.changed
+}
 
-	// Find applicable claims (with wildcard matching)
-	applicable := []Claim{}
-	for _, claim := range ri.artifact.Claims {
-		if ri.matches(claim.Subject, subject) &&
-			ri.matches(claim.Action, action) &&
-			ri.matches(claim.Resource, resource) {
-			applicable = append(applicable, claim)
-		}
+// notifyChanged wakes everyone waiting on Changed. Callers must hold ri.mu for writing.
+func (ri *RuntimeInterface) notifyChanged() {
+	close(ri.changed)
+	ri.changed = make(chan struct{})
+}
+
+// SetKeepPrevious controls whether Swap keeps the artifact it replaces, so that
+// Rollback can restore it. It is off by default; turning it off drops any kept
+// artifact.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) SetKeepPrevious(keep bool) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.keepPrevious = keep
+	if !keep {
+		ri.previous = nil
 	}
+}
 
-	// Check for prohibitions first (highest priority)
-	for _, claim := range applicable {
-		if claim.Type == Prohibition {
-			return map[string]interface{}{
-				"allowed":   false,
-				"authority_id": claim.ID,
-				"reason":    "Prohibited by authority",
-				"scope":     ri.scopeToDict(claim.Scope),
-			}
-		}
+// Generation returns the generation number of the enforced artifact.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) Generation() uint64 {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return ri.generation
+}
+
+// RuntimeSnapshot identifies the artifact a runtime interface enforced at one
+// moment. Changed, if set, is the channel Changed returned at that moment: it is
+// closed once the artifact described is replaced.
+type RuntimeSnapshot struct {
+	Generation uint64
+	ArtifactID string
+	Changed    <-chan struct{}
+}
+
+// Snapshot returns the generation and ID of the enforced artifact and the channel
+// that signals its replacement, read together so that they always describe the
+// same artifact.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) Snapshot() RuntimeSnapshot {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return RuntimeSnapshot{Generation: ri.generation, ArtifactID: ri.artifact.ID, Changed: ri.changed}
+}
+
+// RequestContext describes where, when, and as which operation a request is made.
+// It is checked against claim scope by the InContext query variants.
+// An empty Jurisdiction or Operation means the request does not state one: scoped
+// permissions then do not apply, while scoped prohibitions and obligations still do,
+// so an unstated dimension can never widen authority.
+// A zero Time evaluates the request at the current time.
+type RequestContext struct {
+	Jurisdiction string    `json:"jurisdiction,omitempty"`
+	Operation    string    `json:"operation,omitempty"`
+	Time         time.Time `json:"time,omitempty"`
+}
+
+// Authorize decides a request.
+// The result carries a DecisionProof that VerifyDecisionProof can check.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) Authorize(req AuthorizationRequest) AuthorizationResult {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	return ri.authorize(req)
+}
+
+// AuthorizeBatch decides several requests against the same artifact, taking the
+// lock once. Results are in request order.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) AuthorizeBatch(reqs []AuthorizationRequest) []AuthorizationResult {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	results := make([]AuthorizationResult, len(reqs))
+	for i, req := range reqs {
+		results[i] = ri.authorize(req)
 	}
+	return results
+}
 
-	// Check for permissions
-	for _, claim := range applicable {
-		if claim.Type == Permission {
-			return map[string]interface{}{
-				"allowed":   true,
-				"authority_id": claim.ID,
-				"reason":    "Permitted by authority",
-				"scope":     ri.scopeToDict(claim.Scope),
-			}
-		}
+// AuthorizeWithObligations decides a request and returns the obligations that
+// apply to it, both under the same artifact and at the same evaluation time. Use
+// it rather than Authorize followed by ObligationsFor, between which a Swap may
+// replace the artifact.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) AuthorizeWithObligations(req AuthorizationRequest) (AuthorizationResult, []Claim) {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	proofReq := req.proofRequest()
+	return ri.authorizeResolved(proofReq), ri.obligations(proofReq)
+}
+
+// authorize decides a request. Callers must hold ri.mu.
+func (ri *RuntimeInterface) authorize(req AuthorizationRequest) AuthorizationResult {
+	return ri.authorizeResolved(req.proofRequest())
+}
+
+// authorizeResolved decides a request whose context is already resolved. Callers
+// must hold ri.mu.
+func (ri *RuntimeInterface) authorizeResolved(req ProofRequest) AuthorizationResult {
+	d := ri.evaluate(req)
+	if d.claim == nil {
+		return AuthorizationResult{AuthorityID: ri.artifact.ID, Reason: d.reason, Generation: ri.generation, Proof: d.proof}
+	}
+	return AuthorizationResult{
+		Allowed:     d.allowed,
+		AuthorityID: d.claim.ID,
+		Reason:      d.reason,
+		Scope:       d.claim.Scope,
+		Generation:  ri.generation,
+		Proof:       d.proof,
 	}
+}
 
-	// Fail closed
+// proofRequest returns the request as recorded in proofs, with its context
+// resolved to the evaluation time.
+func (req AuthorizationRequest) proofRequest() ProofRequest {
+	proofReq := ProofRequest{Subject: req.Subject, Action: req.Action, Resource: req.Resource}
+	if req.Context != nil {
+		rc := req.Context.resolved()
+		proofReq.Context = &rc
+	}
+	return proofReq
+}
+
+// IsAuthorized checks if an action is authorized under the given authority.
+// Claim scope is not consulted; use IsAuthorizedInContext to enforce it.
+// The result carries a DecisionProof under "proof"; Authorize returns the same
+// decision as an AuthorizationResult.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) IsAuthorized(subject, action, resource string) map[string]interface{} {
+	return ri.Authorize(AuthorizationRequest{Subject: subject, Action: action, Resource: resource}).toMap()
+}
+
+// IsAuthorizedInContext checks if an action is authorized for a request made in the
+// given context. Claims whose scope does not cover the request are not applicable.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) IsAuthorizedInContext(subject, action, resource string, rc RequestContext) map[string]interface{} {
+	return ri.Authorize(AuthorizationRequest{Subject: subject, Action: action, Resource: resource, Context: &rc}).toMap()
+}
+
+func (r AuthorizationResult) toMap() map[string]interface{} {
+	scope := map[string]interface{}{}
+	if r.Proof.Claim != nil {
+		scope = scopeToDict(r.Scope)
+	}
 	return map[string]interface{}{
-		"allowed":   false,
-		"authority_id": ri.artifact.ID,
-		"reason":    "No applicable authority found - failing closed",
-		"scope":     map[string]interface{}{},
+		"allowed":      r.Allowed,
+		"authority_id": r.AuthorityID,
+		"reason":       r.Reason,
+		"scope":        scope,
+		"generation":   r.Generation,
+		"proof":        r.Proof,
 	}
 }
 
-// GetObligations gets all obligations that apply to this context.
+// decision is the outcome of evaluating one request against the artifact.
+type decision struct {
+	allowed  bool
+	claim    *Claim // deciding claim; nil when failing closed
+	strategy string // conflict strategy that chose the claim, if several applied
+	reason   string
+	proof    DecisionProof
+}
+
+// evaluate decides a request. Callers must hold ri.mu.
+func (ri *RuntimeInterface) evaluate(req ProofRequest) decision {
+	applicable := ri.applicableClaims(req.Subject, req.Action, req.Resource, req.Context)
+	effective := ri.effectiveClaims(applicable)
+
+	d := ri.decide(req.Resource, effective)
+	d.proof = ri.buildProof(req, d, applicable)
+	return d
+}
+
+// decide lets the bound conflict strategy choose among the applicable permissions
+// and prohibitions. Callers must hold ri.mu.
+func (ri *RuntimeInterface) decide(resource string, effective []Claim) decision {
+	deciding := []Claim{}
+	for _, claim := range effective {
+		if claim.Type == Permission || claim.Type == Prohibition {
+			deciding = append(deciding, claim)
+		}
+	}
+	// Fail closed
+	if len(deciding) == 0 {
+		return decision{allowed: false, reason: "No applicable authority found - failing closed"}
+	}
+
+	strategy, err := ri.strategies.forRequest(resource, deciding)
+	if err != nil {
+		return decision{allowed: false, reason: fmt.Sprintf("Conflicting authority could not be resolved - failing closed: %v", err)}
+	}
+	candidates, _ := ri.rank.candidates(deciding) // never fails when not strict
+	chosen, err := strategy.Resolve(candidates)
+	if err != nil {
+		return decision{allowed: false, strategy: strategy.Name(), reason: fmt.Sprintf("Conflicting authority could not be resolved under %s - failing closed: %v", strategy.Name(), err)}
+	}
+
+	d := decision{claim: &chosen.Claim}
+	if len(deciding) > 1 {
+		d.strategy = strategy.Name()
+	}
+	if chosen.Claim.Type == Prohibition {
+		d.reason = "Prohibited by authority"
+	} else {
+		d.allowed = true
+		d.reason = "Permitted by authority"
+	}
+	return d
+}
+
+// ObligationsFor returns the obligations that apply to a request.
 // Thread-safe for concurrent access.
-func (ri *RuntimeInterface) GetObligations(subject, action, resource string) []map[string]interface{} {
+func (ri *RuntimeInterface) ObligationsFor(req AuthorizationRequest) []Claim {
 	ri.mu.RLock()
 	defer ri.mu.RUnlock()
 
-	obligations := []map[string]interface{}{}
-	for _, claim := range ri.artifact.Claims {
+	return ri.obligations(req.proofRequest())
+}
+
+// obligations returns the obligations that apply to a request whose context is
+// already resolved. Callers must hold ri.mu.
+func (ri *RuntimeInterface) obligations(req ProofRequest) []Claim {
+	obligations := []Claim{}
+	for _, claim := range ri.applicableClaims(req.Subject, req.Action, req.Resource, req.Context) {
 		if claim.Type == Obligation {
-			if ri.matches(claim.Subject, subject) &&
-				ri.matches(claim.Action, action) &&
-				ri.matches(claim.Resource, resource) {
-				obligations = append(obligations, map[string]interface{}{
-					"claim_id":   claim.ID,
-					"action":     claim.Action,
-					"scope":      ri.scopeToDict(claim.Scope),
-					"conditions": claim.Conditions,
-				})
-			}
+			obligations = append(obligations, claim)
 		}
 	}
 	return obligations
 }
 
-// GetAuthorityInfo returns detailed information about which authority applies.
+// AuthorityInfoFor returns the claims that apply to a request.
 // Thread-safe for concurrent access.
-func (ri *RuntimeInterface) GetAuthorityInfo(subject, action, resource string) map[string]interface{} {
+func (ri *RuntimeInterface) AuthorityInfoFor(req AuthorizationRequest) AuthorityInfo {
 	ri.mu.RLock()
 	defer ri.mu.RUnlock()
 
-	applicable := []Claim{}
-	for _, claim := range ri.artifact.Claims {
-		if ri.matches(claim.Subject, subject) &&
-			ri.matches(claim.Action, action) &&
-			ri.matches(claim.Resource, resource) {
-			applicable = append(applicable, claim)
-		}
+	return AuthorityInfo{
+		ArtifactID:       ri.artifact.ID,
+		ApplicableClaims: ri.applicable(req),
+		TotalClaims:      len(ri.artifact.Claims),
+	}
+}
+
+// applicable returns the claims applicable to a request. Callers must hold ri.mu.
+func (ri *RuntimeInterface) applicable(req AuthorizationRequest) []Claim {
+	proofReq := req.proofRequest()
+	return ri.applicableClaims(proofReq.Subject, proofReq.Action, proofReq.Resource, proofReq.Context)
+}
+
+// GetObligations gets all obligations that apply to this context.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) GetObligations(subject, action, resource string) []map[string]interface{} {
+	return obligationMaps(ri.ObligationsFor(AuthorizationRequest{Subject: subject, Action: action, Resource: resource}))
+}
+
+// GetObligationsInContext gets all obligations whose scope covers the request context.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) GetObligationsInContext(subject, action, resource string, rc RequestContext) []map[string]interface{} {
+	return obligationMaps(ri.ObligationsFor(AuthorizationRequest{Subject: subject, Action: action, Resource: resource, Context: &rc}))
+}
+
+func obligationMaps(obligations []Claim) []map[string]interface{} {
+	maps := make([]map[string]interface{}, 0, len(obligations))
+	for _, claim := range obligations {
+		maps = append(maps, map[string]interface{}{
+			"claim_id":   claim.ID,
+			"action":     claim.Action,
+			"scope":      scopeToDict(claim.Scope),
+			"conditions": claim.Conditions,
+		})
 	}
+	return maps
+}
 
+// GetAuthorityInfo returns detailed information about which authority applies.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) GetAuthorityInfo(subject, action, resource string) map[string]interface{} {
+	return ri.AuthorityInfoFor(AuthorizationRequest{Subject: subject, Action: action, Resource: resource}).toMap()
+}
+
+// GetAuthorityInfoInContext returns information about the authority that applies
+// to a request made in the given context.
+// Thread-safe for concurrent access.
+func (ri *RuntimeInterface) GetAuthorityInfoInContext(subject, action, resource string, rc RequestContext) map[string]interface{} {
+	return ri.AuthorityInfoFor(AuthorizationRequest{Subject: subject, Action: action, Resource: resource, Context: &rc}).toMap()
+}
+
+func (info AuthorityInfo) toMap() map[string]interface{} {
 	applicableClaims := []map[string]interface{}{}
-	for _, claim := range applicable {
+	for _, claim := range info.ApplicableClaims {
 		applicableClaims = append(applicableClaims, map[string]interface{}{
 			"id":         claim.ID,
 			"type":       claim.Type,
-			"scope":      ri.scopeToDict(claim.Scope),
+			"scope":      scopeToDict(claim.Scope),
 			"conditions": claim.Conditions,
 		})
 	}
 
 	return map[string]interface{}{
-		"artifact_id":     ri.artifact.ID,
+		"artifact_id":       info.ArtifactID,
 		"applicable_claims": applicableClaims,
-		"total_claims":    len(ri.artifact.Claims),
+		"total_claims":      info.TotalClaims,
+	}
+}
+
+// resolved pins an unset evaluation time to now, so the time a decision was made
+// at is recorded in its proof.
+func (rc RequestContext) resolved() RequestContext {
+	if rc.Time.IsZero() {
+		rc.Time = time.Now().UTC()
+	}
+	return rc
+}
+
+// applicableClaims returns the claims matching the request (with wildcard matching),
+// in artifact order. When rc is non-nil, claims whose scope does not cover the
+// request are skipped; rc must have been resolved. Callers must hold ri.mu.
+func (ri *RuntimeInterface) applicableClaims(subject, action, resource string, rc *RequestContext) []Claim {
+	applicable := []Claim{}
+	for _, position := range ri.index.candidates(subject, action, resource) {
+		claim := ri.artifact.Claims[position]
+		if !matchPattern(claim.Subject, subject) ||
+			!matchPattern(claim.Action, action) ||
+			!matchPattern(claim.Resource, resource) {
+			continue
+		}
+		if rc != nil && !scopeCoversRequest(claim, *rc, rc.Time) {
+			continue
+		}
+		applicable = append(applicable, claim)
 	}
+	return applicable
+}
+
+// effectiveClaims drops applicable claims that another applicable claim overrides.
+// Both applying means the request lies where their patterns overlap, which is
+// exactly where conflict resolution gave the overriding claim precedence.
+// Callers must hold ri.mu.
+func (ri *RuntimeInterface) effectiveClaims(applicable []Claim) []Claim {
+	if len(ri.overriddenBy) == 0 {
+		return applicable
+	}
+	ids := make(map[string]bool, len(applicable))
+	for _, claim := range applicable {
+		ids[claim.QualifiedID()] = true
+	}
+	effective := make([]Claim, 0, len(applicable))
+	for _, claim := range applicable {
+		if ri.overridingClaim(claim.QualifiedID(), ids) == "" {
+			effective = append(effective, claim)
+		}
+	}
+	return effective
+}
+
+// overridingClaim returns the first claim among ids that overrides claimID, or "".
+// Both are qualified claim IDs.
+// Callers must hold ri.mu.
+func (ri *RuntimeInterface) overridingClaim(claimID string, ids map[string]bool) string {
+	for _, winner := range ri.overriddenBy[claimID] {
+		if ids[winner] {
+			return winner
+		}
+	}
+	return ""
+}
+
+// scopeCoversRequest reports whether the claim's scope applies to the request.
+// Empty scope sets are universal. An unstated request jurisdiction or operation
+// fails closed: it only satisfies a restricted set for restrictive claims.
+func scopeCoversRequest(claim Claim, rc RequestContext, at time.Time) bool {
+	restrictive := claim.Type == Prohibition || claim.Type == Obligation
+
+	if !scopeSetCovers(claim.Scope.Jurisdictions, rc.Jurisdiction, restrictive) {
+		return false
+	}
+	if !scopeSetCovers(claim.Scope.Operations, rc.Operation, restrictive) {
+		return false
+	}
+	// Time windows are half-open: [TimeStart, TimeEnd)
+	if claim.Scope.TimeStart != nil && at.Before(*claim.Scope.TimeStart) {
+		return false
+	}
+	if claim.Scope.TimeEnd != nil && !at.Before(*claim.Scope.TimeEnd) {
+		return false
+	}
+	return true
+}
+
+func scopeSetCovers(set []string, value string, restrictive bool) bool {
+	if len(set) == 0 {
+		return true
+	}
+	if value == "" {
+		return restrictive
+	}
+	for _, item := range set {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
 
-func (ri *RuntimeInterface) matches(pattern, value string) bool {
+// matchPattern reports whether a claim pattern matches a request value.
+// "*" matches anything, a trailing "/*" matches values under that path, and a
+// trailing "*" matches values with that prefix; anything else matches exactly.
+func matchPattern(pattern, value string) bool {
 	if pattern == "*" {
 		return true
 	}
@@ -162,7 +595,7 @@ func (ri *RuntimeInterface) matches(pattern, value string) bool {
 	return false
 }
 
-func (ri *RuntimeInterface) scopeToDict(scope Scope) map[string]interface{} {
+func scopeToDict(scope Scope) map[string]interface{} {
 	var timeStart, timeEnd interface{}
 	if scope.TimeStart != nil {
 		timeStart = scope.TimeStart.Format(time.RFC3339)
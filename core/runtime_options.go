@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// RuntimeOption adds a check, or a setting, to NewValidatedRuntimeInterface.
+// Checks apply to the initial artifact and stay in force for every artifact
+// later put in force with Swap or SwapVerified.
+type RuntimeOption func(*runtimeOptions)
+
+type runtimeOptions struct {
+	artifactChecks
+	signature    *Signature
+	keepPrevious bool
+}
+
+// artifactChecks are the checks an artifact must pass before a runtime enforces it.
+type artifactChecks struct {
+	verifier Verifier // if set, artifacts must be signed by a key it trusts
+	maxAge   time.Duration
+	clock    func() time.Time
+}
+
+// check validates an artifact and applies the configured checks. sig is the
+// artifact's signature, or nil if it has none.
+func (c artifactChecks) check(artifact AuthorityArtifact, sig *Signature) error {
+	if err := ValidateAirWithErrors(artifact); err != nil {
+		return err
+	}
+	if c.verifier != nil {
+		if sig == nil {
+			return fmt.Errorf("%w: artifact is not signed", ErrInvalidSignature)
+		}
+		if err := VerifyArtifact(c.verifier, artifact, *sig); err != nil {
+			return err
+		}
+	}
+	if c.maxAge > 0 {
+		now := c.clock()
+		if artifact.GeneratedAt.After(now) {
+			return fmt.Errorf("%w: generated at %s, in the future", ErrStaleArtifact, artifact.GeneratedAt.Format(time.RFC3339))
+		}
+		if age := now.Sub(artifact.GeneratedAt); age > c.maxAge {
+			return fmt.Errorf("%w: generated %s ago, limit is %s", ErrStaleArtifact, age.Round(time.Second), c.maxAge)
+		}
+	}
+	return nil
+}
+
+// WithSignature requires sig to be a valid signature of the artifact by a key the
+// verifier trusts. Later artifacts must be put in force with SwapVerified and a
+// signature by a key the verifier trusts.
+func WithSignature(sig Signature, verifier Verifier) RuntimeOption {
+	return func(o *runtimeOptions) {
+		o.signature = &sig
+		o.verifier = verifier
+	}
+}
+
+// WithMaxAge requires the artifact to have been generated no longer than maxAge
+// ago, and not in the future.
+func WithMaxAge(maxAge time.Duration) RuntimeOption {
+	return func(o *runtimeOptions) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithClock sets the clock WithMaxAge measures against (time.Now by default).
+func WithClock(clock func() time.Time) RuntimeOption {
+	return func(o *runtimeOptions) {
+		o.clock = clock
+	}
+}
+
+// WithKeepPrevious enables SetKeepPrevious on the new runtime interface.
+func WithKeepPrevious() RuntimeOption {
+	return func(o *runtimeOptions) {
+		o.keepPrevious = true
+	}
+}
+
+// NewValidatedRuntimeInterface creates a runtime interface only if the artifact
+// passes ValidateAirWithErrors and every check requested through opts, so that a
+// corrupt or hand-edited artifact is never enforced. The runtime keeps the checks:
+// Swap and SwapVerified apply them to every later artifact too.
+func NewValidatedRuntimeInterface(artifact AuthorityArtifact, opts ...RuntimeOption) (*RuntimeInterface, error) {
+	options := runtimeOptions{artifactChecks: artifactChecks{clock: time.Now}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.signature != nil && options.verifier == nil {
+		return nil, fmt.Errorf("%w: no verifier configured", ErrInvalidSignature)
+	}
+
+	if err := options.check(artifact, options.signature); err != nil {
+		return nil, err
+	}
+	ri := NewRuntimeInterface(artifact)
+	ri.checks = options.artifactChecks
+	ri.keepPrevious = options.keepPrevious
+	return ri, nil
+}
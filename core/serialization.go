@@ -0,0 +1,193 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ArtifactFormatVersion is the version of the on-disk artifact format written by
+// MarshalArtifact. UnmarshalArtifact reads this version and every earlier one.
+//
+// Version 1 is a JSON document:
+//
+//	{
+//	  "format_version": 1,
+//	  "artifact": {
+//	    "id": "sha256:...",
+//	    "source_id": "...",            // empty for multi-source artifacts
+//	    "sources": [{"id", "type", "name", "version"}, ...],
+//	    "claims": [{"id", "type", "subject", "action", "resource",
+//	                "scope": {"jurisdictions", "time_start", "time_end", "operations"},
+//	                "conditions", "source_id", "position"}, ...],
+//	    "graph": {
+//	      "nodes": ["source_id:claim_id", ...],  // every node must be one of the claims
+//	      "edges": [{"from", "to", "type"}, ...]
+//	    },
+//	    "timeline": [{"claim_id", "origin_id", "time_start", "time_end", "cut_by"}, ...],  // optional
+//	    "resolutions": [{"claim_id", "by", "edge", "strategy",
+//	                     "winner_key", "loser_key"}, ...],                                 // optional
+//	    "strategies": [{"source_id" or "namespace", "strategy"}, ...],                     // optional
+//	    "generated_at": "RFC3339 timestamp"
+//	  },
+//	  "signature": {"key_id", "algorithm", "digest", "value"}  // optional
+//	}
+//
+// Unknown fields are rejected so that a file written by a newer, incompatible
+// writer is never half-understood.
+const ArtifactFormatVersion = 1
+
+type artifactFile struct {
+	FormatVersion int            `json:"format_version"`
+	Artifact      storedArtifact `json:"artifact"`
+	Signature     *Signature     `json:"signature,omitempty"`
+}
+
+type storedArtifact struct {
+	ID          string             `json:"id"`
+	SourceID    string             `json:"source_id"`
+	Sources     []SourceInfo       `json:"sources"`
+	Claims      []Claim            `json:"claims"`
+	Graph       storedGraph        `json:"graph"`
+	Timeline    []TimeSlice        `json:"timeline,omitempty"`
+	Resolutions []ResolutionRecord `json:"resolutions,omitempty"`
+	Strategies  []StrategyBinding  `json:"strategies,omitempty"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+type storedGraph struct {
+	Nodes []string `json:"nodes"`
+	Edges []Edge   `json:"edges"`
+}
+
+// MarshalArtifact encodes an artifact, and optionally its signature, in the current
+// on-disk format. Claims, nodes, and edges are written in canonical order, so the
+// output is deterministic.
+func MarshalArtifact(artifact AuthorityArtifact, sig *Signature) ([]byte, error) {
+	canonical := canonicalize(artifact)
+	claims := make([]Claim, 0, len(artifact.Claims))
+	byID := make(map[string]Claim, len(artifact.Claims))
+	for _, claim := range artifact.Claims {
+		byID[claim.QualifiedID()] = claim
+	}
+	for _, claim := range canonical.Claims {
+		claims = append(claims, byID[ClaimRef{SourceID: claim.SourceID, ClaimID: claim.ID}.String()])
+	}
+	edges := make([]Edge, 0, len(canonical.Edges))
+	for _, edge := range canonical.Edges {
+		edges = append(edges, Edge{FromID: edge.FromID, ToID: edge.ToID, EdgeType: edge.EdgeType})
+	}
+
+	file := artifactFile{
+		FormatVersion: ArtifactFormatVersion,
+		Artifact: storedArtifact{
+			ID:          artifact.ID,
+			SourceID:    artifact.SourceID,
+			Sources:     canonical.Sources,
+			Claims:      claims,
+			Graph:       storedGraph{Nodes: canonical.Nodes, Edges: edges},
+			Timeline:    artifact.Timeline,
+			Resolutions: artifact.Resolutions,
+			Strategies:  canonical.Strategies,
+			GeneratedAt: artifact.GeneratedAt.UTC(),
+		},
+		Signature: sig,
+	}
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// UnmarshalArtifact decodes an artifact written by MarshalArtifact and validates it
+// with ValidateAirWithErrors. Its ID must be the ContentID of what was read, so an
+// artifact edited after compilation, or one that did not survive encoding intact,
+// is rejected. The signature is nil if the file carries none. It is not checked
+// here: pass it to NewVerifiedRuntimeInterface or VerifyArtifact.
+func UnmarshalArtifact(data []byte) (AuthorityArtifact, *Signature, error) {
+	var header struct {
+		FormatVersion *int `json:"format_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: %v", ErrInvalidArtifactFile, err)
+	}
+	if header.FormatVersion == nil {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: missing format_version", ErrInvalidArtifactFile)
+	}
+	if *header.FormatVersion < 1 || *header.FormatVersion > ArtifactFormatVersion {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: %d (supported: 1 to %d)", ErrUnsupportedFormatVersion, *header.FormatVersion, ArtifactFormatVersion)
+	}
+
+	var file artifactFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: %v", ErrInvalidArtifactFile, err)
+	}
+
+	stored := file.Artifact
+	claims := stored.Claims
+	if claims == nil {
+		claims = []Claim{}
+	}
+	byID := make(map[string]Claim, len(claims))
+	for _, claim := range claims {
+		byID[claim.QualifiedID()] = claim
+	}
+	nodes := make(map[string]Claim, len(stored.Graph.Nodes))
+	for _, id := range stored.Graph.Nodes {
+		claim, ok := byID[id]
+		if !ok {
+			return AuthorityArtifact{}, nil, newValidationError("graph.nodes", fmt.Sprintf("node %s is not a claim of the artifact", id), ErrInvalidEdgeReference)
+		}
+		nodes[id] = claim
+	}
+	edges := stored.Graph.Edges
+	if edges == nil {
+		edges = []Edge{}
+	}
+
+	artifact := AuthorityArtifact{
+		ID:          stored.ID,
+		SourceID:    stored.SourceID,
+		Sources:     stored.Sources,
+		Claims:      claims,
+		Graph:       AuthorityGraph{Nodes: nodes, Edges: edges},
+		Timeline:    stored.Timeline,
+		Resolutions: stored.Resolutions,
+		Strategies:  stored.Strategies,
+		GeneratedAt: stored.GeneratedAt,
+	}
+	if err := ValidateAirWithErrors(artifact); err != nil {
+		return AuthorityArtifact{}, nil, err
+	}
+	id, err := ContentID(artifact)
+	if err != nil {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: %v", ErrInvalidArtifactFile, err)
+	}
+	if id != artifact.ID {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%w: artifact ID %s does not match its content %s", ErrInvalidArtifactFile, artifact.ID, id)
+	}
+	return artifact, file.Signature, nil
+}
+
+// WriteArtifactFile writes an artifact, and optionally its signature, to path.
+func WriteArtifactFile(path string, artifact AuthorityArtifact, sig *Signature) error {
+	data, err := MarshalArtifact(artifact, sig)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadArtifactFile reads and validates an artifact written by WriteArtifactFile.
+func ReadArtifactFile(path string) (AuthorityArtifact, *Signature, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AuthorityArtifact{}, nil, err
+	}
+	artifact, sig, err := UnmarshalArtifact(data)
+	if err != nil {
+		return AuthorityArtifact{}, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return artifact, sig, nil
+}
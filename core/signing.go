@@ -0,0 +1,119 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Signature domains. Each kind of signed payload is prefixed with its own domain so
+// that a signature over a proof can never be replayed as a signature over an artifact.
+const (
+	artifactSignatureDomain = "are-artifact-v1\n"
+	proofSignatureDomain    = "are-proof-v1\n"
+)
+
+// Signer produces detached signatures. Implementations hold the private key: in
+// memory, in a key file, or behind an HSM or KMS that never exposes it.
+type Signer interface {
+	// KeyID identifies the key so verifiers can select the matching public key.
+	KeyID() string
+	// Algorithm names the signature scheme, for example "ed25519".
+	Algorithm() string
+	// Sign signs message and returns the raw signature bytes.
+	Sign(message []byte) ([]byte, error)
+}
+
+// Verifier checks detached signatures against the public keys it trusts.
+type Verifier interface {
+	// Verify returns nil if signature is a valid signature of message by the key
+	// named keyID under algorithm, ErrUnknownKey if the key is not trusted, and
+	// ErrInvalidSignature otherwise.
+	Verify(keyID, algorithm string, message, signature []byte) error
+}
+
+// Signature is a detached signature over an artifact or proof. Digest is the SHA-256
+// of the signed payload, recorded so a mismatch can be reported without the key.
+type Signature struct {
+	KeyID     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	Digest    string `json:"digest"`
+	Value     []byte `json:"value"`
+}
+
+// SignArtifact signs the canonical encoding of an artifact (see CanonicalArtifact).
+func SignArtifact(signer Signer, artifact AuthorityArtifact) (Signature, error) {
+	payload, err := CanonicalArtifact(artifact)
+	if err != nil {
+		return Signature{}, fmt.Errorf("encoding artifact: %w", err)
+	}
+	return sign(signer, artifactSignatureDomain, payload)
+}
+
+// VerifyArtifact checks that sig is a valid signature of the artifact by a key the
+// verifier trusts.
+func VerifyArtifact(verifier Verifier, artifact AuthorityArtifact, sig Signature) error {
+	payload, err := CanonicalArtifact(artifact)
+	if err != nil {
+		return fmt.Errorf("encoding artifact: %w", err)
+	}
+	return verify(verifier, artifactSignatureDomain, payload, sig)
+}
+
+// SignProof signs a proof string as returned by AuthorityCompiler.EmitProof.
+// The proof is signed byte for byte, so it must be stored exactly as emitted.
+func SignProof(signer Signer, proof string) (Signature, error) {
+	return sign(signer, proofSignatureDomain, []byte(proof))
+}
+
+// VerifyProof checks that sig is a valid signature of the proof string by a key the
+// verifier trusts.
+func VerifyProof(verifier Verifier, proof string, sig Signature) error {
+	return verify(verifier, proofSignatureDomain, []byte(proof), sig)
+}
+
+// NewVerifiedRuntimeInterface creates a runtime interface only if the artifact is
+// valid and sig is a valid signature of it by a key the verifier trusts. It is
+// NewValidatedRuntimeInterface with WithSignature.
+func NewVerifiedRuntimeInterface(artifact AuthorityArtifact, sig Signature, verifier Verifier) (*RuntimeInterface, error) {
+	return NewValidatedRuntimeInterface(artifact, WithSignature(sig, verifier))
+}
+
+func sign(signer Signer, domain string, payload []byte) (Signature, error) {
+	if signer == nil {
+		return Signature{}, fmt.Errorf("no signer configured")
+	}
+	digest := payloadDigest(payload)
+	value, err := signer.Sign(signedMessage(domain, digest))
+	if err != nil {
+		return Signature{}, fmt.Errorf("signing with key %s: %w", signer.KeyID(), err)
+	}
+	return Signature{
+		KeyID:     signer.KeyID(),
+		Algorithm: signer.Algorithm(),
+		Digest:    digest,
+		Value:     value,
+	}, nil
+}
+
+func verify(verifier Verifier, domain string, payload []byte, sig Signature) error {
+	if verifier == nil {
+		return fmt.Errorf("%w: no verifier configured", ErrInvalidSignature)
+	}
+	digest := payloadDigest(payload)
+	if sig.Digest != "" && sig.Digest != digest {
+		return fmt.Errorf("%w: payload digest %s does not match signed digest %s", ErrInvalidSignature, digest, sig.Digest)
+	}
+	return verifier.Verify(sig.KeyID, sig.Algorithm, signedMessage(domain, digest), sig.Value)
+}
+
+func payloadDigest(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// signedMessage is the byte string actually signed: the domain followed by the
+// payload digest.
+func signedMessage(domain, digest string) []byte {
+	return []byte(domain + digest)
+}
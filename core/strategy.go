@@ -0,0 +1,286 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Built-in conflict strategy names.
+const (
+	StrategyPrecedence        = "precedence"
+	StrategyDenyOverrides     = "deny-overrides"
+	StrategyPermitOverrides   = "permit-overrides"
+	StrategyFirstApplicable   = "first-applicable"
+	StrategyOnlyOneApplicable = "only-one-applicable"
+)
+
+// StrategyMetadataKey is the AuthoritySource.Metadata key that selects the conflict
+// strategy for conflicts involving the source's claims.
+const StrategyMetadataKey = "conflict_strategy"
+
+// Candidate is a claim competing to decide a request, with the facts a
+// ConflictStrategy may base its choice on.
+type Candidate struct {
+	Claim      Claim
+	Source     SourceInfo
+	Precedence PrecedenceKey
+	Position   int // declaration order of the claim (Claim.Position)
+}
+
+// ConflictStrategy chooses which of several conflicting claims decides. It is
+// applied to overlapping claims at compile time and to applicable claims at
+// runtime.
+type ConflictStrategy interface {
+	// Name identifies the strategy in sources, artifacts, and proofs.
+	Name() string
+	// Resolve returns the deciding candidate. Candidates are never empty and are
+	// ordered by precedence key, then prohibitions before permissions, then claim
+	// ID. An error means the conflict cannot be resolved and must fail closed.
+	Resolve(candidates []Candidate) (Candidate, error)
+}
+
+// StrategyBinding selects the conflict strategy for claims issued by a source or
+// for claims on resources under a namespace. Exactly one of SourceID and
+// Namespace is set; Namespace is a resource pattern such as "/finance/*".
+type StrategyBinding struct {
+	SourceID  string `json:"source_id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Strategy  string `json:"strategy"`
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]ConflictStrategy{}
+)
+
+func init() {
+	for _, strategy := range []ConflictStrategy{
+		precedenceStrategy{},
+		effectOverridesStrategy{name: StrategyDenyOverrides, effect: Prohibition},
+		effectOverridesStrategy{name: StrategyPermitOverrides, effect: Permission},
+		firstApplicableStrategy{},
+		onlyOneApplicableStrategy{},
+	} {
+		RegisterConflictStrategy(strategy)
+	}
+}
+
+// RegisterConflictStrategy makes a strategy available by name to sources and
+// namespace bindings, replacing any strategy registered under the same name.
+func RegisterConflictStrategy(strategy ConflictStrategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[strategy.Name()] = strategy
+}
+
+// LookupConflictStrategy returns the strategy registered under name.
+func LookupConflictStrategy(name string) (ConflictStrategy, error) {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	strategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+	}
+	return strategy, nil
+}
+
+// precedenceStrategy is the default: source type, version, delegation depth, and
+// scope specificity decide. Claims tied on all of them with different effects or
+// conditions are an ambiguous conflict, which fails compilation closed.
+type precedenceStrategy struct{}
+
+func (precedenceStrategy) Name() string { return StrategyPrecedence }
+
+func (precedenceStrategy) Resolve(candidates []Candidate) (Candidate, error) {
+	return candidates[0], nil
+}
+
+// effectOverridesStrategy lets any claim of one type win over claims of the other;
+// precedence decides among claims of the same type.
+type effectOverridesStrategy struct {
+	name   string
+	effect ClaimType
+}
+
+func (s effectOverridesStrategy) Name() string { return s.name }
+
+func (s effectOverridesStrategy) Resolve(candidates []Candidate) (Candidate, error) {
+	for _, candidate := range candidates {
+		if candidate.Claim.Type == s.effect {
+			return candidate, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// firstApplicableStrategy lets the claim declared first win.
+type firstApplicableStrategy struct{}
+
+func (firstApplicableStrategy) Name() string { return StrategyFirstApplicable }
+
+func (firstApplicableStrategy) Resolve(candidates []Candidate) (Candidate, error) {
+	first := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.Position < first.Position {
+			first = candidate
+		}
+	}
+	return first, nil
+}
+
+// onlyOneApplicableStrategy refuses to choose: overlapping claims are an error.
+type onlyOneApplicableStrategy struct{}
+
+func (onlyOneApplicableStrategy) Name() string { return StrategyOnlyOneApplicable }
+
+func (onlyOneApplicableStrategy) Resolve(candidates []Candidate) (Candidate, error) {
+	if len(candidates) > 1 {
+		ids := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			ids[i] = candidate.Claim.QualifiedID()
+		}
+		return Candidate{}, fmt.Errorf("%d claims apply (%v) but only one may", len(ids), ids)
+	}
+	return candidates[0], nil
+}
+
+// ranker builds ordered candidates for the claims of one artifact.
+type ranker struct {
+	sources     map[string]SourceInfo
+	delegations *delegations
+	strict      bool // fail on claims whose source the artifact does not list
+}
+
+func newRanker(artifact AuthorityArtifact, delegations *delegations, strict bool) *ranker {
+	r := &ranker{
+		sources:     make(map[string]SourceInfo, len(artifact.Sources)),
+		delegations: delegations,
+		strict:      strict,
+	}
+	for _, source := range artifact.Sources {
+		r.sources[source.ID] = source
+	}
+	return r
+}
+
+// candidates returns the claims as candidates in the order ConflictStrategy.Resolve expects.
+func (r *ranker) candidates(claims []Claim) ([]Candidate, error) {
+	candidates := make([]Candidate, 0, len(claims))
+	for _, claim := range claims {
+		source, ok := r.sources[claim.SourceID]
+		if !ok {
+			if r.strict {
+				return nil, newValidationError("source_id", fmt.Sprintf("unknown source for claim %s: %s", claim.ID, claim.SourceID), nil)
+			}
+			source = SourceInfo{ID: claim.SourceID}
+		}
+		if r.strict && !IsValidAuthorityType(source.Type) {
+			return nil, newValidationError("source.type", fmt.Sprintf("invalid authority type for source %s: %s", source.ID, source.Type), nil)
+		}
+		candidates = append(candidates, Candidate{
+			Claim:      claim,
+			Source:     source,
+			Precedence: precedenceKey(source.Type, source.Version, claim, r.delegations.depth(claim.QualifiedID())),
+			Position:   claim.Position,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if cmp := a.Precedence.Compare(b.Precedence); cmp != 0 {
+			return cmp < 0
+		}
+		if (a.Claim.Type == Prohibition) != (b.Claim.Type == Prohibition) {
+			return a.Claim.Type == Prohibition
+		}
+		return a.Claim.QualifiedID() < b.Claim.QualifiedID()
+	})
+	return candidates, nil
+}
+
+// strategySelector picks the strategy for a conflict from an artifact's bindings.
+// A namespace binding covering the resource wins, longest namespace first; then
+// the binding of the highest-authority source involved; then the default
+// precedence strategy.
+type strategySelector struct {
+	namespaces []StrategyBinding // longest namespace first
+	sources    map[string]string
+	types      map[string]AuthorityType
+}
+
+func newStrategySelector(artifact AuthorityArtifact) *strategySelector {
+	s := &strategySelector{
+		sources: make(map[string]string),
+		types:   make(map[string]AuthorityType, len(artifact.Sources)),
+	}
+	for _, source := range artifact.Sources {
+		s.types[source.ID] = source.Type
+	}
+	for _, binding := range artifact.Strategies {
+		if binding.Namespace != "" {
+			s.namespaces = append(s.namespaces, binding)
+		} else {
+			s.sources[binding.SourceID] = binding.Strategy
+		}
+	}
+	sort.SliceStable(s.namespaces, func(i, j int) bool {
+		return len(s.namespaces[i].Namespace) > len(s.namespaces[j].Namespace)
+	})
+	return s
+}
+
+// forClaims selects the strategy for a conflict between claims at compile time.
+// A namespace applies only if it covers every claim's resource pattern.
+func (s *strategySelector) forClaims(claims []Claim) (ConflictStrategy, error) {
+	for _, binding := range s.namespaces {
+		namespace := parsePattern(binding.Namespace)
+		covered := true
+		for _, claim := range claims {
+			if !namespace.covers(parsePattern(claim.Resource)) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return LookupConflictStrategy(binding.Strategy)
+		}
+	}
+	return s.forSources(claims)
+}
+
+// forRequest selects the strategy for claims applicable to a request at runtime.
+func (s *strategySelector) forRequest(resource string, claims []Claim) (ConflictStrategy, error) {
+	for _, binding := range s.namespaces {
+		if matchPattern(binding.Namespace, resource) {
+			return LookupConflictStrategy(binding.Strategy)
+		}
+	}
+	return s.forSources(claims)
+}
+
+// forSources returns the strategy bound by the highest-authority source of the
+// claims. Equal-authority sources binding different strategies fail closed.
+func (s *strategySelector) forSources(claims []Claim) (ConflictStrategy, error) {
+	authorityOrder := AuthorityTypeOrder()
+	name, bestOrder := "", len(authorityOrder)+1
+	for _, claim := range claims {
+		bound, ok := s.sources[claim.SourceID]
+		if !ok {
+			continue
+		}
+		order, known := authorityOrder[s.types[claim.SourceID]]
+		if !known {
+			order = len(authorityOrder)
+		}
+		switch {
+		case order < bestOrder:
+			name, bestOrder = bound, order
+		case order == bestOrder && bound != name:
+			return nil, fmt.Errorf("%w: sources of equal authority bind both %s and %s", ErrUnresolvableConflict, name, bound)
+		}
+	}
+	if name == "" {
+		name = StrategyPrecedence
+	}
+	return LookupConflictStrategy(name)
+}
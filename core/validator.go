@@ -22,16 +22,17 @@ func ValidateAirWithErrors(artifact AuthorityArtifact) error {
 		return nil
 	}
 
+	// Claim IDs need only be unique within their source.
 	seenClaimIDs := make(map[string]bool)
 	for _, claim := range artifact.Claims {
-		if seenClaimIDs[claim.ID] {
+		if seenClaimIDs[claim.QualifiedID()] {
 			return &ValidationError{
 				Field:   "claim.ID",
-				Message: fmt.Sprintf("duplicate claim ID: %s", claim.ID),
+				Message: fmt.Sprintf("duplicate claim ID in source %s: %s", claim.SourceID, claim.ID),
 				Err:     ErrInvalidClaim,
 			}
 		}
-		seenClaimIDs[claim.ID] = true
+		seenClaimIDs[claim.QualifiedID()] = true
 		if err := validateClaimWithErrors(claim, artifact.Graph); err != nil {
 			return err
 		}
@@ -91,7 +92,7 @@ func validateDelegationClaim(claim Claim, graph AuthorityGraph) bool {
 	// Find delegator (parent in graph)
 	delegatorClaim := Claim{}
 	for _, edge := range graph.Edges {
-		if edge.ToID == claim.ID && edge.EdgeType == Delegates {
+		if edge.ToID == claim.QualifiedID() && edge.EdgeType == Delegates {
 			delegatorClaim = graph.Nodes[edge.FromID]
 			break
 		}
@@ -0,0 +1,191 @@
+package decisionpb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"are/core"
+)
+
+// FromRequest converts a core request to its protobuf form.
+func FromRequest(req core.AuthorizationRequest) *AuthorizationRequest {
+	msg := &AuthorizationRequest{Subject: req.Subject, Action: req.Action, Resource: req.Resource}
+	if req.Context != nil {
+		msg.Context = &RequestContext{
+			Jurisdiction: req.Context.Jurisdiction,
+			Operation:    req.Context.Operation,
+			Time:         fromTime(req.Context.Time),
+		}
+	}
+	return msg
+}
+
+// ToCore converts the request to its core form.
+func (x *AuthorizationRequest) ToCore() core.AuthorizationRequest {
+	req := core.AuthorizationRequest{Subject: x.GetSubject(), Action: x.GetAction(), Resource: x.GetResource()}
+	if rc := x.GetContext(); rc != nil {
+		req.Context = &core.RequestContext{
+			Jurisdiction: rc.GetJurisdiction(),
+			Operation:    rc.GetOperation(),
+			Time:         toTime(rc.GetTime()),
+		}
+	}
+	return req
+}
+
+// FromResult converts a core result to its protobuf form.
+func FromResult(result core.AuthorizationResult) (*AuthorizationResult, error) {
+	proof, err := json.Marshal(result.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding decision proof: %w", err)
+	}
+	return &AuthorizationResult{
+		Allowed:     result.Allowed,
+		AuthorityId: result.AuthorityID,
+		Reason:      result.Reason,
+		Scope:       fromScope(result.Scope),
+		Generation:  result.Generation,
+		Proof:       proof,
+	}, nil
+}
+
+// ToCore converts the result to its core form.
+func (x *AuthorizationResult) ToCore() (core.AuthorizationResult, error) {
+	result := core.AuthorizationResult{
+		Allowed:     x.GetAllowed(),
+		AuthorityID: x.GetAuthorityId(),
+		Reason:      x.GetReason(),
+		Scope:       x.GetScope().toCore(),
+		Generation:  x.GetGeneration(),
+	}
+	if err := json.Unmarshal(x.GetProof(), &result.Proof); err != nil {
+		return core.AuthorizationResult{}, fmt.Errorf("decoding decision proof: %w", err)
+	}
+	return result, nil
+}
+
+// FromClaims converts core claims to their protobuf form.
+func FromClaims(claims []core.Claim) ([]*Claim, error) {
+	msgs := make([]*Claim, 0, len(claims))
+	for _, claim := range claims {
+		conditions, err := fromConditions(claim.Conditions)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s: %w", claim.ID, err)
+		}
+		msgs = append(msgs, &Claim{
+			Id:         claim.ID,
+			Type:       string(claim.Type),
+			Subject:    claim.Subject,
+			Action:     claim.Action,
+			Resource:   claim.Resource,
+			Scope:      fromScope(claim.Scope),
+			Conditions: conditions,
+			SourceId:   claim.SourceID,
+			Position:   int32(claim.Position),
+		})
+	}
+	return msgs, nil
+}
+
+// ToCoreClaims converts protobuf claims to their core form.
+func ToCoreClaims(msgs []*Claim) []core.Claim {
+	claims := make([]core.Claim, 0, len(msgs))
+	for _, msg := range msgs {
+		claim := core.Claim{
+			ID:       msg.GetId(),
+			Type:     core.ClaimType(msg.GetType()),
+			Subject:  msg.GetSubject(),
+			Action:   msg.GetAction(),
+			Resource: msg.GetResource(),
+			Scope:    msg.GetScope().toCore(),
+			SourceID: msg.GetSourceId(),
+			Position: int(msg.GetPosition()),
+		}
+		if msg.GetConditions() != nil {
+			claim.Conditions = msg.GetConditions().AsMap()
+		}
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// FromAuthorityInfo converts core authority info to its protobuf form.
+func FromAuthorityInfo(info core.AuthorityInfo) (*AuthorityInfo, error) {
+	claims, err := FromClaims(info.ApplicableClaims)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityInfo{
+		ArtifactId:       info.ArtifactID,
+		ApplicableClaims: claims,
+		TotalClaims:      int64(info.TotalClaims),
+	}, nil
+}
+
+// ToCore converts the authority info to its core form.
+func (x *AuthorityInfo) ToCore() core.AuthorityInfo {
+	return core.AuthorityInfo{
+		ArtifactID:       x.GetArtifactId(),
+		ApplicableClaims: ToCoreClaims(x.GetApplicableClaims()),
+		TotalClaims:      int(x.GetTotalClaims()),
+	}
+}
+
+func fromScope(scope core.Scope) *Scope {
+	msg := &Scope{Jurisdictions: scope.Jurisdictions, Operations: scope.Operations}
+	if scope.TimeStart != nil {
+		msg.TimeStart = timestamppb.New(*scope.TimeStart)
+	}
+	if scope.TimeEnd != nil {
+		msg.TimeEnd = timestamppb.New(*scope.TimeEnd)
+	}
+	return msg
+}
+
+func (x *Scope) toCore() core.Scope {
+	scope := core.Scope{Jurisdictions: x.GetJurisdictions(), Operations: x.GetOperations()}
+	if x.GetTimeStart() != nil {
+		t := x.GetTimeStart().AsTime()
+		scope.TimeStart = &t
+	}
+	if x.GetTimeEnd() != nil {
+		t := x.GetTimeEnd().AsTime()
+		scope.TimeEnd = &t
+	}
+	return scope
+}
+
+// fromConditions converts claim conditions through their JSON encoding, which is
+// how the compiler normalizes them, so every value has a Struct representation.
+func fromConditions(conditions map[string]interface{}) (*structpb.Struct, error) {
+	if conditions == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("encoding conditions: %w", err)
+	}
+	var plain map[string]interface{}
+	if err := json.Unmarshal(encoded, &plain); err != nil {
+		return nil, fmt.Errorf("encoding conditions: %w", err)
+	}
+	return structpb.NewStruct(plain)
+}
+
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,292 @@
+// Decision service of the Authority Realization Engine. It mirrors the query
+// methods of core.RuntimeInterface; see the grpcserver and grpcclient packages.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: decisionpb/decision.proto
+
+package decisionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DecisionService_IsAuthorized_FullMethodName     = "/are.decision.v1.DecisionService/IsAuthorized"
+	DecisionService_AuthorizeBatch_FullMethodName   = "/are.decision.v1.DecisionService/AuthorizeBatch"
+	DecisionService_GetObligations_FullMethodName   = "/are.decision.v1.DecisionService/GetObligations"
+	DecisionService_GetAuthorityInfo_FullMethodName = "/are.decision.v1.DecisionService/GetAuthorityInfo"
+	DecisionService_WatchGeneration_FullMethodName  = "/are.decision.v1.DecisionService/WatchGeneration"
+)
+
+// DecisionServiceClient is the client API for DecisionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DecisionServiceClient interface {
+	// IsAuthorized decides one request.
+	IsAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResult, error)
+	// AuthorizeBatch decides several requests against the same artifact.
+	AuthorizeBatch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// GetObligations returns the obligations that apply to a request.
+	GetObligations(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*ObligationsResponse, error)
+	// GetAuthorityInfo returns the claims that apply to a request.
+	GetAuthorityInfo(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorityInfo, error)
+	// WatchGeneration sends the current artifact generation, then one event each
+	// time the enforced artifact is replaced.
+	WatchGeneration(ctx context.Context, in *WatchGenerationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerationEvent], error)
+}
+
+type decisionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDecisionServiceClient(cc grpc.ClientConnInterface) DecisionServiceClient {
+	return &decisionServiceClient{cc}
+}
+
+func (c *decisionServiceClient) IsAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorizationResult)
+	err := c.cc.Invoke(ctx, DecisionService_IsAuthorized_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) AuthorizeBatch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, DecisionService_AuthorizeBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) GetObligations(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*ObligationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ObligationsResponse)
+	err := c.cc.Invoke(ctx, DecisionService_GetObligations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) GetAuthorityInfo(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorityInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthorityInfo)
+	err := c.cc.Invoke(ctx, DecisionService_GetAuthorityInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *decisionServiceClient) WatchGeneration(ctx context.Context, in *WatchGenerationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerationEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DecisionService_ServiceDesc.Streams[0], DecisionService_WatchGeneration_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchGenerationRequest, GenerationEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DecisionService_WatchGenerationClient = grpc.ServerStreamingClient[GenerationEvent]
+
+// DecisionServiceServer is the server API for DecisionService service.
+// All implementations must embed UnimplementedDecisionServiceServer
+// for forward compatibility.
+type DecisionServiceServer interface {
+	// IsAuthorized decides one request.
+	IsAuthorized(context.Context, *AuthorizationRequest) (*AuthorizationResult, error)
+	// AuthorizeBatch decides several requests against the same artifact.
+	AuthorizeBatch(context.Context, *BatchRequest) (*BatchResponse, error)
+	// GetObligations returns the obligations that apply to a request.
+	GetObligations(context.Context, *AuthorizationRequest) (*ObligationsResponse, error)
+	// GetAuthorityInfo returns the claims that apply to a request.
+	GetAuthorityInfo(context.Context, *AuthorizationRequest) (*AuthorityInfo, error)
+	// WatchGeneration sends the current artifact generation, then one event each
+	// time the enforced artifact is replaced.
+	WatchGeneration(*WatchGenerationRequest, grpc.ServerStreamingServer[GenerationEvent]) error
+	mustEmbedUnimplementedDecisionServiceServer()
+}
+
+// UnimplementedDecisionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDecisionServiceServer struct{}
+
+func (UnimplementedDecisionServiceServer) IsAuthorized(context.Context, *AuthorizationRequest) (*AuthorizationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthorized not implemented")
+}
+func (UnimplementedDecisionServiceServer) AuthorizeBatch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeBatch not implemented")
+}
+func (UnimplementedDecisionServiceServer) GetObligations(context.Context, *AuthorizationRequest) (*ObligationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObligations not implemented")
+}
+func (UnimplementedDecisionServiceServer) GetAuthorityInfo(context.Context, *AuthorizationRequest) (*AuthorityInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorityInfo not implemented")
+}
+func (UnimplementedDecisionServiceServer) WatchGeneration(*WatchGenerationRequest, grpc.ServerStreamingServer[GenerationEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGeneration not implemented")
+}
+func (UnimplementedDecisionServiceServer) mustEmbedUnimplementedDecisionServiceServer() {}
+func (UnimplementedDecisionServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeDecisionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DecisionServiceServer will
+// result in compilation errors.
+type UnsafeDecisionServiceServer interface {
+	mustEmbedUnimplementedDecisionServiceServer()
+}
+
+func RegisterDecisionServiceServer(s grpc.ServiceRegistrar, srv DecisionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDecisionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DecisionService_ServiceDesc, srv)
+}
+
+func _DecisionService_IsAuthorized_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).IsAuthorized(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_IsAuthorized_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).IsAuthorized(ctx, req.(*AuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_AuthorizeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).AuthorizeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_AuthorizeBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).AuthorizeBatch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_GetObligations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).GetObligations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_GetObligations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).GetObligations(ctx, req.(*AuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_GetAuthorityInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DecisionServiceServer).GetAuthorityInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DecisionService_GetAuthorityInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DecisionServiceServer).GetAuthorityInfo(ctx, req.(*AuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DecisionService_WatchGeneration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGenerationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DecisionServiceServer).WatchGeneration(m, &grpc.GenericServerStream[WatchGenerationRequest, GenerationEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DecisionService_WatchGenerationServer = grpc.ServerStreamingServer[GenerationEvent]
+
+// DecisionService_ServiceDesc is the grpc.ServiceDesc for DecisionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DecisionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "are.decision.v1.DecisionService",
+	HandlerType: (*DecisionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IsAuthorized",
+			Handler:    _DecisionService_IsAuthorized_Handler,
+		},
+		{
+			MethodName: "AuthorizeBatch",
+			Handler:    _DecisionService_AuthorizeBatch_Handler,
+		},
+		{
+			MethodName: "GetObligations",
+			Handler:    _DecisionService_GetObligations_Handler,
+		},
+		{
+			MethodName: "GetAuthorityInfo",
+			Handler:    _DecisionService_GetAuthorityInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGeneration",
+			Handler:       _DecisionService_WatchGeneration_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "decisionpb/decision.proto",
+}
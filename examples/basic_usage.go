@@ -70,16 +70,16 @@ func main() {
 		runtime := core.NewRuntimeInterface(success.Artifact)
 
 		// Engineer should be able to read
-		authResult := runtime.IsAuthorized("engineer", "read", "/repos/main.py")
-		fmt.Printf("\nEngineer reading /repos/main.py: %s\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult["allowed"].(bool)])
+		authResult := runtime.Authorize(core.AuthorizationRequest{Subject: "engineer", Action: "read", Resource: "/repos/main.py"})
+		fmt.Printf("\nEngineer reading /repos/main.py: %s\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult.Allowed])
 
 		// Intern should not be able to write
-		authResult = runtime.IsAuthorized("intern", "write", "/repos/main.py")
-		fmt.Printf("Intern writing /repos/main.py: %s\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult["allowed"].(bool)])
+		authResult = runtime.Authorize(core.AuthorizationRequest{Subject: "intern", Action: "write", Resource: "/repos/main.py"})
+		fmt.Printf("Intern writing /repos/main.py: %s\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult.Allowed])
 
 		// Unknown subject should fail closed
-		authResult = runtime.IsAuthorized("unknown", "read", "/repos/main.py")
-		fmt.Printf("Unknown subject reading /repos/main.py: %s (fail-closed)\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult["allowed"].(bool)])
+		authResult = runtime.Authorize(core.AuthorizationRequest{Subject: "unknown", Action: "read", Resource: "/repos/main.py"})
+		fmt.Printf("Unknown subject reading /repos/main.py: %s (fail-closed)\n", map[bool]string{true: "ALLOWED", false: "DENIED"}[authResult.Allowed])
 
 	} else if failure, ok := result.(core.CompilationFailure); ok {
 		log.Fatalf("✗ Compilation failed at stage '%s': %s", failure.FailureStage, failure.ViolatedInvariant)
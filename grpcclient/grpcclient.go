@@ -0,0 +1,100 @@
+// Package grpcclient queries a remote DecisionService (see the grpcserver package)
+// with the request and result types of the core package.
+package grpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"are/core"
+	"are/decisionpb"
+)
+
+// Client queries a DecisionService over a gRPC connection.
+type Client struct {
+	service decisionpb.DecisionServiceClient
+}
+
+// New creates a client using conn, typically a *grpc.ClientConn. The caller
+// remains responsible for closing the connection.
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{service: decisionpb.NewDecisionServiceClient(conn)}
+}
+
+// Authorize decides one request.
+func (c *Client) Authorize(ctx context.Context, req core.AuthorizationRequest) (core.AuthorizationResult, error) {
+	msg, err := c.service.IsAuthorized(ctx, decisionpb.FromRequest(req))
+	if err != nil {
+		return core.AuthorizationResult{}, err
+	}
+	return msg.ToCore()
+}
+
+// AuthorizeBatch decides several requests against the same artifact. Results are
+// in request order.
+func (c *Client) AuthorizeBatch(ctx context.Context, reqs []core.AuthorizationRequest) ([]core.AuthorizationResult, error) {
+	batch := &decisionpb.BatchRequest{}
+	for _, req := range reqs {
+		batch.Requests = append(batch.Requests, decisionpb.FromRequest(req))
+	}
+	resp, err := c.service.AuthorizeBatch(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]core.AuthorizationResult, 0, len(resp.GetResults()))
+	for _, msg := range resp.GetResults() {
+		result, err := msg.ToCore()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ObligationsFor returns the obligations that apply to a request.
+func (c *Client) ObligationsFor(ctx context.Context, req core.AuthorizationRequest) ([]core.Claim, error) {
+	resp, err := c.service.GetObligations(ctx, decisionpb.FromRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return decisionpb.ToCoreClaims(resp.GetObligations()), nil
+}
+
+// AuthorityInfoFor returns the claims that apply to a request.
+func (c *Client) AuthorityInfoFor(ctx context.Context, req core.AuthorizationRequest) (core.AuthorityInfo, error) {
+	resp, err := c.service.GetAuthorityInfo(ctx, decisionpb.FromRequest(req))
+	if err != nil {
+		return core.AuthorityInfo{}, err
+	}
+	return resp.ToCore(), nil
+}
+
+// Generation is an artifact generation put in force by the server.
+type Generation struct {
+	Generation uint64
+	ArtifactID string
+}
+
+// WatchGeneration calls fn with the server's current generation and then with each
+// new one, until ctx is done, the stream fails, or fn returns an error. It returns
+// nil when ctx is done.
+func (c *Client) WatchGeneration(ctx context.Context, fn func(Generation) error) error {
+	stream, err := c.service.WatchGeneration(ctx, &decisionpb.WatchGenerationRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := fn(Generation{Generation: event.GetGeneration(), ArtifactID: event.GetArtifactId()}); err != nil {
+			return err
+		}
+	}
+}
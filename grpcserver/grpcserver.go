@@ -0,0 +1,123 @@
+// Package grpcserver serves authorization decisions from a core.RuntimeInterface
+// as the gRPC DecisionService defined in decisionpb/decision.proto.
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"are/core"
+	"are/decisionpb"
+)
+
+// Server implements decisionpb.DecisionServiceServer on top of a RuntimeInterface.
+type Server struct {
+	decisionpb.UnimplementedDecisionServiceServer
+	runtime *core.RuntimeInterface
+}
+
+// New creates a server for the runtime interface.
+func New(runtime *core.RuntimeInterface) *Server {
+	return &Server{runtime: runtime}
+}
+
+// Register registers the server's DecisionService with a gRPC server.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	decisionpb.RegisterDecisionServiceServer(registrar, s)
+}
+
+// IsAuthorized decides one request.
+func (s *Server) IsAuthorized(ctx context.Context, msg *decisionpb.AuthorizationRequest) (*decisionpb.AuthorizationResult, error) {
+	req, err := request(msg)
+	if err != nil {
+		return nil, err
+	}
+	return result(s.runtime.Authorize(req))
+}
+
+// AuthorizeBatch decides several requests against the same artifact.
+func (s *Server) AuthorizeBatch(ctx context.Context, msg *decisionpb.BatchRequest) (*decisionpb.BatchResponse, error) {
+	reqs := make([]core.AuthorizationRequest, 0, len(msg.GetRequests()))
+	for _, reqMsg := range msg.GetRequests() {
+		req, err := request(reqMsg)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	resp := &decisionpb.BatchResponse{}
+	for _, r := range s.runtime.AuthorizeBatch(reqs) {
+		resultMsg, err := result(r)
+		if err != nil {
+			return nil, err
+		}
+		resp.Results = append(resp.Results, resultMsg)
+	}
+	return resp, nil
+}
+
+// GetObligations returns the obligations that apply to a request.
+func (s *Server) GetObligations(ctx context.Context, msg *decisionpb.AuthorizationRequest) (*decisionpb.ObligationsResponse, error) {
+	req, err := request(msg)
+	if err != nil {
+		return nil, err
+	}
+	obligations, err := decisionpb.FromClaims(s.runtime.ObligationsFor(req))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &decisionpb.ObligationsResponse{Obligations: obligations}, nil
+}
+
+// GetAuthorityInfo returns the claims that apply to a request.
+func (s *Server) GetAuthorityInfo(ctx context.Context, msg *decisionpb.AuthorizationRequest) (*decisionpb.AuthorityInfo, error) {
+	req, err := request(msg)
+	if err != nil {
+		return nil, err
+	}
+	info, err := decisionpb.FromAuthorityInfo(s.runtime.AuthorityInfoFor(req))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return info, nil
+}
+
+// WatchGeneration sends the current generation, then one event for every
+// generation put in force by Swap or Rollback, until the client goes away.
+// Generations replaced again before an event could be sent are skipped.
+func (s *Server) WatchGeneration(msg *decisionpb.WatchGenerationRequest, stream decisionpb.DecisionService_WatchGenerationServer) error {
+	var sent uint64
+	for {
+		snapshot := s.runtime.Snapshot()
+		if snapshot.Generation != sent {
+			event := &decisionpb.GenerationEvent{Generation: snapshot.Generation, ArtifactId: snapshot.ArtifactID}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			sent = snapshot.Generation
+		}
+		select {
+		case <-snapshot.Changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func request(msg *decisionpb.AuthorizationRequest) (core.AuthorizationRequest, error) {
+	if msg.GetSubject() == "" || msg.GetAction() == "" || msg.GetResource() == "" {
+		return core.AuthorizationRequest{}, status.Error(codes.InvalidArgument, "subject, action, and resource are required")
+	}
+	return msg.ToCore(), nil
+}
+
+func result(r core.AuthorizationResult) (*decisionpb.AuthorizationResult, error) {
+	msg, err := decisionpb.FromResult(r)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return msg, nil
+}
@@ -0,0 +1,457 @@
+// Package loader reads authority sources from YAML and JSON files.
+//
+// A file holds one or more source documents. YAML files may separate documents
+// with "---"; JSON files may hold a stream of objects. In either format a document
+// may also be a list of sources. Each source has the form:
+//
+//	id: gdpr
+//	type: regulatory
+//	name: General Data Protection Regulation
+//	description: EU data protection law
+//	version: 1.0.0
+//	metadata: {}            # optional; free-form, must not contain "claims"
+//	claims:
+//	  - id: export_restricted
+//	    type: prohibition
+//	    subject: analyst
+//	    action: export
+//	    resource: /customers/*
+//	    scope:
+//	      jurisdictions: [EU]
+//	      operations: [bulk_export]
+//	      time_start: 2018-05-25T00:00:00Z
+//	      time_end: 2030-01-01T00:00:00Z
+//	    conditions:
+//	      supersedes: company_policy:export_allowed
+//
+// Decoding is strict: unknown fields are rejected, and every error is reported with
+// the file, line, and column it originates from, including claim and scope errors
+// surfaced by core.ParseClaim.
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"are/core"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error reports a load failure at a position in an input file.
+// Line and Column are 1-based; zero means the position is unknown.
+type Error struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnsupportedFormat indicates a file extension the loader does not recognize.
+var ErrUnsupportedFormat = errors.New("unsupported source file format")
+
+var (
+	sourceFields = []string{"id", "type", "name", "description", "version", "metadata", "claims"}
+	claimFields  = []string{"id", "type", "subject", "action", "resource", "scope", "conditions"}
+	scopeFields  = []string{"jurisdictions", "operations", "time_start", "time_end"}
+)
+
+// yamlLineRegex extracts the position from yaml.v3 syntax and type errors.
+var yamlLineRegex = regexp.MustCompile(`line (\d+): (.*)`)
+
+type sourceDocument struct {
+	ID          string                 `yaml:"id"`
+	Type        string                 `yaml:"type"`
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Version     string                 `yaml:"version"`
+	Metadata    map[string]interface{} `yaml:"metadata"`
+	Claims      []claimDocument        `yaml:"claims"`
+}
+
+type claimDocument struct {
+	ID         string                 `yaml:"id"`
+	Type       string                 `yaml:"type"`
+	Subject    string                 `yaml:"subject"`
+	Action     string                 `yaml:"action"`
+	Resource   string                 `yaml:"resource"`
+	Scope      scopeDocument          `yaml:"scope"`
+	Conditions map[string]interface{} `yaml:"conditions"`
+}
+
+type scopeDocument struct {
+	Jurisdictions []string `yaml:"jurisdictions"`
+	Operations    []string `yaml:"operations"`
+	TimeStart     string   `yaml:"time_start"`
+	TimeEnd       string   `yaml:"time_end"`
+}
+
+// toMap renders the claim in the Metadata["claims"] shape consumed by the compiler.
+func (d claimDocument) toMap() map[string]interface{} {
+	scope := map[string]interface{}{}
+	if d.Scope.Jurisdictions != nil {
+		scope["jurisdictions"] = d.Scope.Jurisdictions
+	}
+	if d.Scope.Operations != nil {
+		scope["operations"] = d.Scope.Operations
+	}
+	if d.Scope.TimeStart != "" {
+		scope["time_start"] = d.Scope.TimeStart
+	}
+	if d.Scope.TimeEnd != "" {
+		scope["time_end"] = d.Scope.TimeEnd
+	}
+
+	claim := map[string]interface{}{
+		"id":       d.ID,
+		"type":     d.Type,
+		"subject":  d.Subject,
+		"action":   d.Action,
+		"resource": d.Resource,
+		"scope":    scope,
+	}
+	if d.Conditions != nil {
+		claim["conditions"] = d.Conditions
+	}
+	return claim
+}
+
+// LoadFile reads the authority sources in a file, choosing the format by extension
+// (.yaml, .yml, or .json).
+func LoadFile(path string) ([]core.AuthoritySource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return LoadYAML(path, f)
+	case ".json":
+		return LoadJSON(path, f)
+	default:
+		return nil, &Error{File: path, Err: ErrUnsupportedFormat}
+	}
+}
+
+// LoadFiles reads the authority sources in every file, in order.
+func LoadFiles(paths ...string) ([]core.AuthoritySource, error) {
+	sources := []core.AuthoritySource{}
+	for _, path := range paths {
+		loaded, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, loaded...)
+	}
+	return sources, nil
+}
+
+// LoadYAML reads authority sources from YAML. name is used in error positions.
+func LoadYAML(name string, r io.Reader) ([]core.AuthoritySource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &Error{File: name, Err: err}
+	}
+	return decodeDocuments(name, data)
+}
+
+// LoadJSON reads authority sources from JSON. name is used in error positions.
+// Each top-level value is read with encoding/json, which enforces JSON syntax, and
+// then decoded through the YAML decoder (JSON being a subset of YAML) so positions
+// are reported the same way for both formats.
+func LoadJSON(name string, r io.Reader) ([]core.AuthoritySource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &Error{File: name, Err: err}
+	}
+
+	sources := []core.AuthoritySource{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		prev := dec.InputOffset()
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			loadErr := &Error{File: name, Err: err}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				loadErr.Line, loadErr.Column = offsetPosition(data, syntaxErr.Offset)
+			}
+			return nil, loadErr
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			return nil, yamlError(name, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		start := prev + int64(len(data[prev:])-len(bytes.TrimLeft(data[prev:], " \t\r\n")))
+		line, column := offsetPosition(data, start)
+		shiftPositions(doc.Content[0], line-1, column-1)
+
+		decoded, err := decodeRoot(name, doc.Content[0])
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, decoded...)
+	}
+	return sources, nil
+}
+
+// shiftPositions moves node positions of a value parsed in isolation to where the
+// value sits in its file.
+func shiftPositions(node *yaml.Node, lines, columns int) {
+	if node.Line == 1 {
+		node.Column += columns
+	}
+	node.Line += lines
+	for _, child := range node.Content {
+		shiftPositions(child, lines, columns)
+	}
+}
+
+func decodeDocuments(name string, data []byte) ([]core.AuthoritySource, error) {
+	sources := []core.AuthoritySource{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, yamlError(name, err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+
+		decoded, err := decodeRoot(name, doc.Content[0])
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, decoded...)
+	}
+	return sources, nil
+}
+
+// decodeRoot decodes a document holding a source or a list of sources.
+func decodeRoot(name string, root *yaml.Node) ([]core.AuthoritySource, error) {
+	switch root.Kind {
+	case yaml.MappingNode:
+		source, err := decodeSource(name, root)
+		if err != nil {
+			return nil, err
+		}
+		return []core.AuthoritySource{source}, nil
+	case yaml.SequenceNode:
+		sources := make([]core.AuthoritySource, 0, len(root.Content))
+		for _, item := range root.Content {
+			source, err := decodeSource(name, item)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, source)
+		}
+		return sources, nil
+	default:
+		return nil, nodeError(name, root, errors.New("document must be a source or a list of sources"))
+	}
+}
+
+func decodeSource(name string, node *yaml.Node) (core.AuthoritySource, error) {
+	if node.Kind != yaml.MappingNode {
+		return core.AuthoritySource{}, nodeError(name, node, errors.New("source must be a mapping"))
+	}
+	if err := checkFields(name, node, sourceFields, "source"); err != nil {
+		return core.AuthoritySource{}, err
+	}
+
+	claimNodes := []*yaml.Node{}
+	if claimsNode := mappingValue(node, "claims"); claimsNode != nil {
+		if claimsNode.Kind != yaml.SequenceNode {
+			return core.AuthoritySource{}, nodeError(name, claimsNode, errors.New("claims must be a list"))
+		}
+		for _, claimNode := range claimsNode.Content {
+			if claimNode.Kind != yaml.MappingNode {
+				return core.AuthoritySource{}, nodeError(name, claimNode, errors.New("claim must be a mapping"))
+			}
+			if err := checkFields(name, claimNode, claimFields, "claim"); err != nil {
+				return core.AuthoritySource{}, err
+			}
+			if scopeNode := mappingValue(claimNode, "scope"); scopeNode != nil {
+				if err := checkFields(name, scopeNode, scopeFields, "scope"); err != nil {
+					return core.AuthoritySource{}, err
+				}
+			}
+			claimNodes = append(claimNodes, claimNode)
+		}
+	}
+
+	var doc sourceDocument
+	if err := node.Decode(&doc); err != nil {
+		return core.AuthoritySource{}, yamlError(name, err)
+	}
+
+	if doc.ID == "" {
+		return core.AuthoritySource{}, nodeError(name, node, core.ErrEmptySourceID)
+	}
+	if !core.IsValidAuthorityType(core.AuthorityType(doc.Type)) {
+		return core.AuthoritySource{}, fieldError(name, node, "type", fmt.Errorf("invalid authority type: %q", doc.Type))
+	}
+
+	claims := make([]interface{}, 0, len(doc.Claims))
+	for i, claimDoc := range doc.Claims {
+		claim := claimDoc.toMap()
+		if _, err := core.ParseClaim(claim, doc.ID); err != nil {
+			return core.AuthoritySource{}, claimError(name, claimNodes[i], err)
+		}
+		claims = append(claims, claim)
+	}
+
+	metadata := make(map[string]interface{}, len(doc.Metadata)+1)
+	for k, v := range doc.Metadata {
+		metadata[k] = v
+	}
+	if _, exists := metadata["claims"]; exists {
+		return core.AuthoritySource{}, fieldError(name, node, "metadata", errors.New(`metadata must not contain "claims"; declare claims at the source level`))
+	}
+	metadata["claims"] = claims
+
+	return core.AuthoritySource{
+		ID:          doc.ID,
+		Type:        core.AuthorityType(doc.Type),
+		Name:        doc.Name,
+		Description: doc.Description,
+		Version:     doc.Version,
+		Metadata:    metadata,
+	}, nil
+}
+
+// checkFields rejects mapping keys outside the allowed set.
+func checkFields(name string, node *yaml.Node, allowed []string, kind string) error {
+	if node.Kind != yaml.MappingNode {
+		return nodeError(name, node, fmt.Errorf("%s must be a mapping", kind))
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		known := false
+		for _, field := range allowed {
+			if key.Value == field {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nodeError(name, key, fmt.Errorf("unknown %s field %q", kind, key.Value))
+		}
+	}
+	return nil
+}
+
+// claimError positions a core.ParseClaim error at the field it concerns.
+func claimError(name string, claimNode *yaml.Node, err error) error {
+	var validationErr *core.ValidationError
+	if errors.As(err, &validationErr) {
+		path := strings.Split(validationErr.Field, ".")
+		node := claimNode
+		for _, key := range path {
+			next := mappingKeyOrValue(node, key)
+			if next == nil {
+				break
+			}
+			node = next
+		}
+		return nodeError(name, node, err)
+	}
+	return nodeError(name, claimNode, err)
+}
+
+// fieldError positions an error at a mapping key, falling back to the mapping itself.
+func fieldError(name string, node *yaml.Node, key string, err error) error {
+	if target := mappingKeyOrValue(node, key); target != nil {
+		return nodeError(name, target, err)
+	}
+	return nodeError(name, node, err)
+}
+
+// mappingKeyOrValue returns the value node for key when it is a nested mapping
+// (so paths can continue into it) and the key node otherwise.
+func mappingKeyOrValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			if node.Content[i+1].Kind == yaml.MappingNode {
+				return node.Content[i+1]
+			}
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func nodeError(name string, node *yaml.Node, err error) error {
+	return &Error{File: name, Line: node.Line, Column: node.Column, Err: err}
+}
+
+// yamlError converts yaml.v3 errors, which embed "line N:" in their text, to positioned errors.
+func yamlError(name string, err error) error {
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	if m := yamlLineRegex.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &Error{File: name, Line: line, Err: errors.New(m[2])}
+	}
+	return &Error{File: name, Err: err}
+}
+
+// offsetPosition converts a byte offset into a 1-based line and column.
+func offsetPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	prefix := data[:offset]
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(prefix, '\n')
+	return line, column
+}
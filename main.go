@@ -1,53 +1,11 @@
 package main
 
 import (
-	"fmt"
-//	"os"
-	"time"
-//	"are/core"
-)
-
-// StdLogger implements core.Logger using standard output.
-type StdLogger struct{}
+	"os"
 
-func (l *StdLogger) Debug(msg string, args ...interface{}) {
-	fmt.Printf("[DEBUG] "+msg+"\n", args...)
-}
-func (l *StdLogger) Info(msg string, args ...interface{}) {
-	fmt.Printf("[INFO] "+msg+"\n", args...)
-}
-func (l *StdLogger) Warn(msg string, args ...interface{}) {
-	fmt.Printf("[WARN] "+msg+"\n", args...)
-}
-func (l *StdLogger) Error(msg string, args ...interface{}) {
-	fmt.Printf("[ERROR] "+msg+"\n", args...)
-}
+	"are/cli"
+)
 
 func main() {
-	fmt.Println("are layer running...")
-/*
-	fmt.Println("Authority Realization Engine (ARE) - Go Port")
-	fmt.Println("Version: 1.0.0")
-	fmt.Println()
-	// Initialize compiler with logger
-	compiler := core.NewAuthorityCompiler()
-	compiler.SetLogger(&StdLogger{})
-
-	// Display usage information
-	fmt.Println("Usage:")
-	fmt.Println("  Import are/core in your Go application")
-	fmt.Println("  See examples/basic_usage.go for integration patterns")
-	fmt.Println()
-	fmt.Println("Core Components:")
-	fmt.Println("  - AuthorityCompiler: Transforms authority sources into executable artifacts")
-	fmt.Println("  - RuntimeInterface: Query compiled authority for authorization decisions")
-	fmt.Println("  - ValidateAir: Validate authority artifacts for structural correctness")
-	fmt.Println()
-	fmt.Println("For detailed documentation, see README.md")
-
-	os.Exit(0)
-*/
-    for {
-        time.Sleep(time.Hour)
-    }
-}
\ No newline at end of file
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,207 @@
+// Package middleware enforces compiled authority on net/http handlers.
+//
+// An Enforcer maps each incoming request to a subject, action, and resource with
+// pluggable Extractors, decides it with
+// core.RuntimeInterface.AuthorizeWithObligations, and only calls the wrapped
+// handler if the request is permitted. Anything else, including a request the
+// extractors cannot map, is denied with 403 and a DenyResponse body.
+// Permitted handlers find the decision and the obligations that apply to the
+// request in its context:
+//
+//	enforcer := middleware.New(runtime, middleware.Header("X-User"),
+//		middleware.WithAction(middleware.MethodActions(map[string]string{"GET": "read", "PUT": "write"})))
+//	mux.Handle("/repos/", enforcer.Wrap(reposHandler))
+//
+//	func reposHandler(w http.ResponseWriter, r *http.Request) {
+//		for _, obligation := range middleware.Obligations(r.Context()) { ... }
+//	}
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"are/core"
+)
+
+// Extractor derives one field of the authorization request from an HTTP request.
+// An error, or an empty value, denies the request.
+type Extractor func(r *http.Request) (string, error)
+
+// ContextExtractor derives the request context that claim scope is checked
+// against. A nil context means scope is not consulted.
+type ContextExtractor func(r *http.Request) (*core.RequestContext, error)
+
+// Header extracts the value of a request header.
+func Header(name string) Extractor {
+	return func(r *http.Request) (string, error) {
+		value := r.Header.Get(name)
+		if value == "" {
+			return "", fmt.Errorf("missing %s header", name)
+		}
+		return value, nil
+	}
+}
+
+// Method extracts the HTTP method, lowercased, as the action.
+func Method() Extractor {
+	return func(r *http.Request) (string, error) {
+		return strings.ToLower(r.Method), nil
+	}
+}
+
+// MethodActions maps HTTP methods to actions. Methods missing from the map are
+// denied.
+func MethodActions(actions map[string]string) Extractor {
+	return func(r *http.Request) (string, error) {
+		action, ok := actions[r.Method]
+		if !ok {
+			return "", fmt.Errorf("no action for method %s", r.Method)
+		}
+		return action, nil
+	}
+}
+
+// Path extracts the URL path as the resource.
+func Path() Extractor {
+	return func(r *http.Request) (string, error) {
+		return r.URL.Path, nil
+	}
+}
+
+// Option configures an Enforcer.
+type Option func(*Enforcer)
+
+// WithAction sets the action extractor (Method by default).
+func WithAction(extract Extractor) Option {
+	return func(e *Enforcer) {
+		e.action = extract
+	}
+}
+
+// WithResource sets the resource extractor (Path by default).
+func WithResource(extract Extractor) Option {
+	return func(e *Enforcer) {
+		e.resource = extract
+	}
+}
+
+// WithContext sets the request context extractor. Without one, claim scope is
+// not consulted.
+func WithContext(extract ContextExtractor) Option {
+	return func(e *Enforcer) {
+		e.context = extract
+	}
+}
+
+// Enforcer is a policy enforcement point in front of HTTP handlers.
+type Enforcer struct {
+	runtime  *core.RuntimeInterface
+	subject  Extractor
+	action   Extractor
+	resource Extractor
+	context  ContextExtractor
+}
+
+// New creates an enforcer deciding requests with runtime. There is no default
+// subject: how a caller is identified is always an explicit choice.
+func New(runtime *core.RuntimeInterface, subject Extractor, opts ...Option) *Enforcer {
+	e := &Enforcer{runtime: runtime, subject: subject, action: Method(), resource: Path()}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// DenyResponse is the JSON body of a 403 response.
+type DenyResponse struct {
+	Error       string `json:"error"`
+	Reason      string `json:"reason"`
+	AuthorityID string `json:"authority_id,omitempty"`
+	Generation  uint64 `json:"generation,omitempty"`
+}
+
+// Wrap returns a handler that calls next only for permitted requests.
+func (e *Enforcer) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := e.request(r)
+		if err != nil {
+			deny(w, DenyResponse{Error: "forbidden", Reason: fmt.Sprintf("request could not be mapped to authority - failing closed: %v", err)})
+			return
+		}
+		result, obligations := e.runtime.AuthorizeWithObligations(req)
+		if !result.Allowed {
+			deny(w, DenyResponse{Error: "forbidden", Reason: result.Reason, AuthorityID: result.AuthorityID, Generation: result.Generation})
+			return
+		}
+		ctx := context.WithValue(r.Context(), decisionKey{}, decision{
+			result:      result,
+			obligations: obligations,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (e *Enforcer) request(r *http.Request) (core.AuthorizationRequest, error) {
+	var req core.AuthorizationRequest
+	fields := []struct {
+		name    string
+		extract Extractor
+		value   *string
+	}{
+		{"subject", e.subject, &req.Subject},
+		{"action", e.action, &req.Action},
+		{"resource", e.resource, &req.Resource},
+	}
+	for _, field := range fields {
+		if field.extract == nil {
+			return req, fmt.Errorf("no %s extractor configured", field.name)
+		}
+		value, err := field.extract(r)
+		if err != nil {
+			return req, fmt.Errorf("%s: %w", field.name, err)
+		}
+		if value == "" {
+			return req, fmt.Errorf("%s: empty", field.name)
+		}
+		*field.value = value
+	}
+	if e.context != nil {
+		rc, err := e.context(r)
+		if err != nil {
+			return req, fmt.Errorf("context: %w", err)
+		}
+		req.Context = rc
+	}
+	return req, nil
+}
+
+func deny(w http.ResponseWriter, body DenyResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+type decisionKey struct{}
+
+type decision struct {
+	result      core.AuthorizationResult
+	obligations []core.Claim
+}
+
+// Decision returns the decision that let the request through, if ctx belongs to
+// a request passed by an Enforcer.
+func Decision(ctx context.Context) (core.AuthorizationResult, bool) {
+	d, ok := ctx.Value(decisionKey{}).(decision)
+	return d.result, ok
+}
+
+// Obligations returns the obligations that apply to the request ctx belongs to.
+// Handlers are expected to discharge them.
+func Obligations(ctx context.Context) []core.Claim {
+	d, _ := ctx.Value(decisionKey{}).(decision)
+	return d.obligations
+}
@@ -0,0 +1,226 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind classifies a token of the policy language.
+type TokenKind int
+
+const (
+	// TokenEOF marks the end of input.
+	TokenEOF TokenKind = iota
+	// TokenNewline ends a statement. Indented lines continue the previous
+	// statement and produce no newline token.
+	TokenNewline
+	// TokenWord is any run of characters other than whitespace, ',', '=', and '"'.
+	TokenWord
+	// TokenString is a double-quoted string; Text holds the unquoted value.
+	TokenString
+	// TokenComma separates list items.
+	TokenComma
+	// TokenEquals separates condition keys from values.
+	TokenEquals
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenEOF:
+		return "end of input"
+	case TokenNewline:
+		return "end of line"
+	case TokenWord:
+		return "word"
+	case TokenString:
+		return "string"
+	case TokenComma:
+		return "','"
+	case TokenEquals:
+		return "'='"
+	default:
+		return fmt.Sprintf("token(%d)", int(k))
+	}
+}
+
+// Position is a 1-based location in policy source text.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Token is a lexical token with its position.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Pos  Position
+}
+
+// Error reports a policy syntax or semantic error at a position.
+type Error struct {
+	File string
+	Pos  Position
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	msg := e.Msg
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", e.Msg, e.Err)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Pos.Line, e.Pos.Column, msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Tokenize splits policy source into tokens. name is used in error positions.
+// '#' starts a comment when it begins a token and runs to the end of the line.
+func Tokenize(name string, src []byte) ([]Token, error) {
+	lx := lexer{name: name, src: string(src), line: 1, col: 1}
+	return lx.run()
+}
+
+type lexer struct {
+	name   string
+	src    string
+	offset int
+	line   int
+	col    int
+	tokens []Token
+}
+
+func (lx *lexer) peek() rune {
+	if lx.offset >= len(lx.src) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(lx.src[lx.offset:])
+	return r
+}
+
+func (lx *lexer) next() rune {
+	r, size := utf8.DecodeRuneInString(lx.src[lx.offset:])
+	lx.offset += size
+	if r == '\n' {
+		lx.line++
+		lx.col = 1
+	} else {
+		lx.col++
+	}
+	return r
+}
+
+func (lx *lexer) emit(kind TokenKind, text string, pos Position) {
+	lx.tokens = append(lx.tokens, Token{Kind: kind, Text: text, Pos: pos})
+}
+
+func (lx *lexer) run() ([]Token, error) {
+	for lx.offset < len(lx.src) {
+		r := lx.peek()
+		pos := Position{Line: lx.line, Column: lx.col}
+
+		switch {
+		case r == '\n':
+			lx.next()
+			lx.newline(pos)
+		case unicode.IsSpace(r):
+			lx.next()
+		case r == '#':
+			for lx.offset < len(lx.src) && lx.peek() != '\n' {
+				lx.next()
+			}
+		case r == ',':
+			lx.next()
+			lx.emit(TokenComma, ",", pos)
+		case r == '=':
+			lx.next()
+			lx.emit(TokenEquals, "=", pos)
+		case r == '"':
+			text, err := lx.quoted()
+			if err != nil {
+				return nil, &Error{File: lx.name, Pos: pos, Msg: err.Error()}
+			}
+			lx.emit(TokenString, text, pos)
+		default:
+			start := lx.offset
+			for lx.offset < len(lx.src) {
+				c := lx.peek()
+				if unicode.IsSpace(c) || c == ',' || c == '=' || c == '"' {
+					break
+				}
+				lx.next()
+			}
+			lx.emit(TokenWord, lx.src[start:lx.offset], pos)
+		}
+	}
+
+	end := Position{Line: lx.line, Column: lx.col}
+	lx.newline(end)
+	lx.emit(TokenEOF, "", end)
+	return lx.tokens, nil
+}
+
+// newline ends the current statement unless the following non-blank line is
+// indented, in which case it continues the statement.
+func (lx *lexer) newline(pos Position) {
+	if len(lx.tokens) == 0 || lx.tokens[len(lx.tokens)-1].Kind == TokenNewline {
+		return
+	}
+	rest := lx.src[lx.offset:]
+	for {
+		line := rest
+		if i := strings.IndexByte(rest, '\n'); i >= 0 {
+			line = rest[:i]
+			rest = rest[i+1:]
+		} else {
+			rest = ""
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			if rest == "" {
+				break
+			}
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return
+		}
+		break
+	}
+	lx.emit(TokenNewline, "", pos)
+}
+
+func (lx *lexer) quoted() (string, error) {
+	lx.next() // opening quote
+	var b strings.Builder
+	for lx.offset < len(lx.src) {
+		r := lx.next()
+		switch r {
+		case '"':
+			return b.String(), nil
+		case '\n':
+			return "", fmt.Errorf("unterminated string")
+		case '\\':
+			if lx.offset >= len(lx.src) {
+				return "", fmt.Errorf("unterminated string")
+			}
+			switch esc := lx.next(); esc {
+			case '"', '\\':
+				b.WriteRune(esc)
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			default:
+				return "", fmt.Errorf("unknown escape \\%c", esc)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
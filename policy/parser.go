@@ -0,0 +1,388 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"are/core"
+)
+
+var claimVerbs = map[string]core.ClaimType{
+	"PERMIT":   core.Permission,
+	"PROHIBIT": core.Prohibition,
+	"OBLIGE":   core.Obligation,
+	"DELEGATE": core.Delegation,
+}
+
+// reservedConditions are condition keys set by dedicated clauses rather than WHERE.
+var reservedConditions = map[string]string{
+	"revokes":      "REVOKES",
+	"supersedes":   "SUPERSEDES",
+	"delegates_to": "DELEGATES TO",
+}
+
+// Parse parses policy source text. name is used in error positions.
+func Parse(name string, src []byte) (*File, error) {
+	tokens, err := Tokenize(name, src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{file: &File{Name: name}, tokens: tokens}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.file, nil
+}
+
+type parser struct {
+	file    *File
+	tokens  []Token
+	pos     int
+	current string // source ID claims default to
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != TokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(pos Position, format string, args ...interface{}) error {
+	return &Error{File: p.file.Name, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// atEnd reports whether the current statement has no more tokens.
+func (p *parser) atEnd() bool {
+	kind := p.peek().Kind
+	return kind == TokenNewline || kind == TokenEOF
+}
+
+// keyword reports whether the next token is the given keyword, consuming it if so.
+func (p *parser) keyword(kw string) bool {
+	tok := p.peek()
+	if tok.Kind == TokenWord && strings.EqualFold(tok.Text, kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// value reads a word or string.
+func (p *parser) value(what string) (Token, error) {
+	tok := p.next()
+	if tok.Kind != TokenWord && tok.Kind != TokenString {
+		return tok, p.errorf(tok.Pos, "expected %s, found %s", what, describe(tok))
+	}
+	if tok.Text == "" {
+		return tok, p.errorf(tok.Pos, "%s must not be empty", what)
+	}
+	return tok, nil
+}
+
+// list reads one or more comma-separated values.
+func (p *parser) list(what string) ([]string, error) {
+	var values []string
+	for {
+		tok, err := p.value(what)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, tok.Text)
+		if p.peek().Kind != TokenComma {
+			return values, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) refs() ([]core.ClaimRef, error) {
+	start := p.peek().Pos
+	values, err := p.list("claim reference")
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]core.ClaimRef, 0, len(values))
+	for _, value := range values {
+		ref, err := core.ParseClaimRef(value)
+		if err != nil {
+			return nil, &Error{File: p.file.Name, Pos: start, Msg: "invalid claim reference", Err: err}
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+func (p *parser) parse() error {
+	for {
+		tok := p.peek()
+		switch tok.Kind {
+		case TokenEOF:
+			return p.resolve()
+		case TokenNewline:
+			p.next()
+			continue
+		case TokenWord:
+		default:
+			return p.errorf(tok.Pos, "expected statement, found %s", describe(tok))
+		}
+
+		upper := strings.ToUpper(tok.Text)
+		var err error
+		if upper == "SOURCE" {
+			err = p.parseSource()
+		} else if claimType, ok := claimVerbs[upper]; ok {
+			err = p.parseClaim(claimType)
+		} else {
+			err = p.errorf(tok.Pos, "unknown statement %q; expected SOURCE, PERMIT, PROHIBIT, OBLIGE, or DELEGATE", tok.Text)
+		}
+		if err != nil {
+			return err
+		}
+
+		if end := p.peek(); end.Kind != TokenNewline && end.Kind != TokenEOF {
+			return p.errorf(end.Pos, "unexpected %s", describe(end))
+		}
+	}
+}
+
+func (p *parser) parseSource() error {
+	start := p.next().Pos
+	idTok, err := p.value("source ID")
+	if err != nil {
+		return err
+	}
+	typeTok, err := p.value("authority type")
+	if err != nil {
+		return err
+	}
+	authorityType := core.AuthorityType(strings.ToLower(typeTok.Text))
+	if !core.IsValidAuthorityType(authorityType) {
+		return p.errorf(typeTok.Pos, "invalid authority type %q", typeTok.Text)
+	}
+	for _, existing := range p.file.Sources {
+		if existing.ID == idTok.Text {
+			return p.errorf(idTok.Pos, "source %s already declared at line %d", idTok.Text, existing.Pos.Line)
+		}
+	}
+
+	decl := &SourceDecl{Pos: start, ID: idTok.Text, Type: authorityType}
+	seen := make(map[string]bool)
+	for !p.atEnd() {
+		kwTok := p.next()
+		kw := strings.ToUpper(kwTok.Text)
+		if kwTok.Kind != TokenWord || (kw != "VERSION" && kw != "NAME" && kw != "DESCRIPTION") {
+			return p.errorf(kwTok.Pos, "expected VERSION, NAME, or DESCRIPTION, found %s", describe(kwTok))
+		}
+		if seen[kw] {
+			return p.errorf(kwTok.Pos, "duplicate %s clause", kw)
+		}
+		seen[kw] = true
+		valueTok, err := p.value(strings.ToLower(kw))
+		if err != nil {
+			return err
+		}
+		switch kw {
+		case "VERSION":
+			decl.Version = valueTok.Text
+		case "NAME":
+			decl.Name = valueTok.Text
+		case "DESCRIPTION":
+			decl.Description = valueTok.Text
+		}
+	}
+
+	p.file.Sources = append(p.file.Sources, decl)
+	p.current = decl.ID
+	return nil
+}
+
+func (p *parser) parseClaim(claimType core.ClaimType) error {
+	start := p.next().Pos
+	subject, err := p.value("subject")
+	if err != nil {
+		return err
+	}
+	action, err := p.value("action")
+	if err != nil {
+		return err
+	}
+	resource, err := p.value("resource")
+	if err != nil {
+		return err
+	}
+
+	decl := &ClaimDecl{
+		Pos:      start,
+		Type:     claimType,
+		Subject:  subject.Text,
+		Action:   strings.ToLower(action.Text),
+		Resource: resource.Text,
+		SourceID: p.current,
+	}
+
+	seen := make(map[string]bool)
+	for !p.atEnd() {
+		kwTok := p.next()
+		if kwTok.Kind != TokenWord {
+			return p.errorf(kwTok.Pos, "expected clause keyword, found %s", describe(kwTok))
+		}
+		kw := strings.ToUpper(kwTok.Text)
+		if kw == "DELEGATES" {
+			if !p.keyword("TO") {
+				return p.errorf(p.peek().Pos, "expected TO after DELEGATES")
+			}
+			kw = "DELEGATES TO"
+		}
+		if seen[kw] {
+			return p.errorf(kwTok.Pos, "duplicate %s clause", kw)
+		}
+		seen[kw] = true
+
+		switch kw {
+		case "IN":
+			decl.Jurisdictions, err = p.list("jurisdiction")
+		case "FOR":
+			decl.Operations, err = p.list("operation")
+		case "DURING":
+			err = p.parseWindow(decl)
+		case "UNDER":
+			p.keyword("SOURCE")
+			var tok Token
+			if tok, err = p.value("source ID"); err == nil {
+				decl.SourceID = tok.Text
+			}
+		case "AS":
+			var tok Token
+			if tok, err = p.value("claim ID"); err == nil {
+				decl.ID = tok.Text
+			}
+		case "REVOKES":
+			decl.Revokes, err = p.refs()
+		case "SUPERSEDES":
+			decl.Supersedes, err = p.refs()
+		case "DELEGATES TO":
+			decl.DelegatesTo, err = p.refs()
+		case "WHERE":
+			err = p.parseConditions(decl)
+		default:
+			err = p.errorf(kwTok.Pos, "unknown clause %q", kwTok.Text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if decl.SourceID == "" {
+		return p.errorf(start, "claim has no source; declare a SOURCE first or add UNDER SOURCE")
+	}
+	p.file.Claims = append(p.file.Claims, decl)
+	return nil
+}
+
+func (p *parser) parseWindow(decl *ClaimDecl) error {
+	tok, err := p.value("time window")
+	if err != nil {
+		return err
+	}
+	startText, endText, ok := strings.Cut(tok.Text, "..")
+	if !ok {
+		return p.errorf(tok.Pos, "time window must have the form start..end")
+	}
+	if startText == "" && endText == "" {
+		return p.errorf(tok.Pos, "time window must bound at least one end")
+	}
+	if startText != "" {
+		if decl.TimeStart, err = parseInstant(startText); err != nil {
+			return p.errorf(tok.Pos, "invalid window start %q: use YYYY-MM-DD or RFC3339", startText)
+		}
+	}
+	if endText != "" {
+		if decl.TimeEnd, err = parseInstant(endText); err != nil {
+			return p.errorf(tok.Pos, "invalid window end %q: use YYYY-MM-DD or RFC3339", endText)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseConditions(decl *ClaimDecl) error {
+	decl.Conditions = make(map[string]string)
+	for {
+		keyTok, err := p.value("condition key")
+		if err != nil {
+			return err
+		}
+		if clause, reserved := reservedConditions[keyTok.Text]; reserved {
+			return p.errorf(keyTok.Pos, "condition %s must be written as a %s clause", keyTok.Text, clause)
+		}
+		if _, dup := decl.Conditions[keyTok.Text]; dup {
+			return p.errorf(keyTok.Pos, "duplicate condition %s", keyTok.Text)
+		}
+		if eq := p.next(); eq.Kind != TokenEquals {
+			return p.errorf(eq.Pos, "expected '=' after condition key, found %s", describe(eq))
+		}
+		valueTok, err := p.value("condition value")
+		if err != nil {
+			return err
+		}
+		decl.Conditions[keyTok.Text] = valueTok.Text
+		if p.peek().Kind != TokenComma {
+			return nil
+		}
+		p.next()
+	}
+}
+
+// resolve checks cross-statement references and assigns default claim IDs.
+func (p *parser) resolve() error {
+	declared := make(map[string]bool)
+	for _, source := range p.file.Sources {
+		declared[source.ID] = true
+	}
+
+	counts := make(map[string]int)
+	ids := make(map[string]*ClaimDecl)
+	for _, decl := range p.file.Claims {
+		if !declared[decl.SourceID] {
+			return p.errorf(decl.Pos, "claim references undeclared source %s", decl.SourceID)
+		}
+		counts[decl.SourceID]++
+		if decl.ID == "" {
+			decl.ID = fmt.Sprintf("%s_%d", decl.SourceID, counts[decl.SourceID])
+		}
+		if prev, dup := ids[decl.ID]; dup {
+			return p.errorf(decl.Pos, "claim ID %s already used at line %d", decl.ID, prev.Pos.Line)
+		}
+		ids[decl.ID] = decl
+	}
+	return nil
+}
+
+// parseInstant accepts a calendar date (midnight UTC) or an RFC3339 timestamp.
+func parseInstant(text string) (*time.Time, error) {
+	if t, err := time.Parse("2006-01-02", text); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func describe(tok Token) string {
+	switch tok.Kind {
+	case TokenWord:
+		return fmt.Sprintf("%q", tok.Text)
+	case TokenString:
+		return fmt.Sprintf("string %q", tok.Text)
+	default:
+		return tok.Kind.String()
+	}
+}
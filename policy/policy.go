@@ -0,0 +1,206 @@
+// Package policy implements a small declarative language for authority claims.
+//
+// A policy file declares sources and the claims they issue, one statement per
+// line. Indented lines continue the previous statement; '#' starts a comment.
+// Keywords are case-insensitive.
+//
+//	SOURCE company_policy ORGANIZATIONAL VERSION 2.1.0 NAME "Engineering Access Policy"
+//
+//	PERMIT engineer READ /repos/* IN US,EU FOR read,clone AS eng_read
+//	PROHIBIT intern WRITE /repos/* IN US,EU DURING 2025-01-01..2026-01-01 UNDER SOURCE company_policy
+//	OBLIGE engineer AUDIT /repos/* AS audit_trail WHERE retention = "90d"
+//	PERMIT admin GRANT /data AS data_admin DELEGATES TO data_reader
+//	DELEGATE service READ /data IN US FOR read AS data_reader
+//	PROHIBIT ops RETAIN /logs/* AS retention_limit
+//	    REVOKES company_policy:retain_logs
+//
+// A claim statement is a verb (PERMIT, PROHIBIT, OBLIGE, or DELEGATE) followed by
+// subject, action, and resource, then optional clauses in any order:
+//
+//	IN j1,j2                  scope jurisdictions
+//	FOR op1,op2               scope operations
+//	DURING start..end         scope time window; dates or RFC3339, either end optional
+//	UNDER [SOURCE] id         issuing source; defaults to the last SOURCE declared
+//	AS id                     claim ID; defaults to <source>_<n>
+//	REVOKES ref,...           claims revoked, as claim_id or source_id:claim_id
+//	SUPERSEDES ref,...        claims superseded
+//	DELEGATES TO ref,...      delegation claims this claim delegates to
+//	WHERE key = value,...     additional claim conditions
+//
+// Actions are case-insensitive and normalized to lower case. Words containing
+// spaces, commas, or '=' must be quoted.
+//
+// Parsed files compile into the same core.Claim and core.Scope structures the
+// compiler's Normalize stage produces, and Format prints a file back in canonical
+// form so policy text can be round-tripped.
+package policy
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"are/core"
+)
+
+// File is a parsed policy file.
+type File struct {
+	Name    string
+	Sources []*SourceDecl
+	Claims  []*ClaimDecl
+}
+
+// SourceDecl declares an authority source.
+type SourceDecl struct {
+	Pos         Position
+	ID          string
+	Type        core.AuthorityType
+	Version     string
+	Name        string
+	Description string
+}
+
+// ClaimDecl declares a claim issued by a source.
+type ClaimDecl struct {
+	Pos           Position
+	ID            string
+	Type          core.ClaimType
+	Subject       string
+	Action        string
+	Resource      string
+	SourceID      string
+	Jurisdictions []string
+	Operations    []string
+	TimeStart     *time.Time
+	TimeEnd       *time.Time
+	Revokes       []core.ClaimRef
+	Supersedes    []core.ClaimRef
+	DelegatesTo   []core.ClaimRef
+	Conditions    map[string]string
+}
+
+// ParseFile reads and parses a policy file.
+func ParseFile(path string) (*File, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, src)
+}
+
+// AuthoritySources returns the file's sources in declaration order, each carrying
+// its claims in Metadata["claims"] ready for the compiler. Claims are checked with
+// core.ParseClaim and errors are reported at the statement that declared them.
+func (f *File) AuthoritySources() ([]core.AuthoritySource, error) {
+	claimsBySource := make(map[string][]interface{})
+	for _, decl := range f.Claims {
+		claim := decl.toMap()
+		if _, err := core.ParseClaim(claim, decl.SourceID); err != nil {
+			return nil, &Error{File: f.Name, Pos: decl.Pos, Msg: "invalid claim " + decl.ID, Err: err}
+		}
+		claimsBySource[decl.SourceID] = append(claimsBySource[decl.SourceID], claim)
+	}
+
+	sources := make([]core.AuthoritySource, 0, len(f.Sources))
+	for _, decl := range f.Sources {
+		claims := claimsBySource[decl.ID]
+		if claims == nil {
+			claims = []interface{}{}
+		}
+		sources = append(sources, core.AuthoritySource{
+			ID:          decl.ID,
+			Type:        decl.Type,
+			Name:        decl.Name,
+			Description: decl.Description,
+			Version:     decl.Version,
+			Metadata:    map[string]interface{}{"claims": claims},
+		})
+	}
+	return sources, nil
+}
+
+// CoreClaims returns the file's claims as core.Claim values, exactly as Normalize
+// would produce them from AuthoritySources, positions included.
+func (f *File) CoreClaims() ([]core.Claim, error) {
+	positions := make(map[*ClaimDecl]int, len(f.Claims))
+	for _, source := range f.Sources {
+		for _, decl := range f.Claims {
+			if decl.SourceID == source.ID {
+				positions[decl] = len(positions)
+			}
+		}
+	}
+
+	claims := make([]core.Claim, 0, len(f.Claims))
+	for _, decl := range f.Claims {
+		claim, err := core.ParseClaim(decl.toMap(), decl.SourceID)
+		if err != nil {
+			return nil, &Error{File: f.Name, Pos: decl.Pos, Msg: "invalid claim " + decl.ID, Err: err}
+		}
+		claim.Position = positions[decl]
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// toMap renders the declaration in the Metadata["claims"] shape consumed by the compiler.
+func (d *ClaimDecl) toMap() map[string]interface{} {
+	scope := map[string]interface{}{}
+	if len(d.Jurisdictions) > 0 {
+		scope["jurisdictions"] = append([]string(nil), d.Jurisdictions...)
+	}
+	if len(d.Operations) > 0 {
+		scope["operations"] = append([]string(nil), d.Operations...)
+	}
+	if d.TimeStart != nil {
+		scope["time_start"] = d.TimeStart.Format(time.RFC3339)
+	}
+	if d.TimeEnd != nil {
+		scope["time_end"] = d.TimeEnd.Format(time.RFC3339)
+	}
+
+	claim := map[string]interface{}{
+		"id":       d.ID,
+		"type":     string(d.Type),
+		"subject":  d.Subject,
+		"action":   d.Action,
+		"resource": d.Resource,
+		"scope":    scope,
+	}
+
+	conditions := make(map[string]interface{})
+	for key, value := range d.Conditions {
+		conditions[key] = value
+	}
+	for key, refs := range map[string][]core.ClaimRef{
+		"revokes":      d.Revokes,
+		"supersedes":   d.Supersedes,
+		"delegates_to": d.DelegatesTo,
+	} {
+		switch len(refs) {
+		case 0:
+		case 1:
+			conditions[key] = refs[0].String()
+		default:
+			values := make([]interface{}, len(refs))
+			for i, ref := range refs {
+				values[i] = ref.String()
+			}
+			conditions[key] = values
+		}
+	}
+	if len(conditions) > 0 {
+		claim["conditions"] = conditions
+	}
+	return claim
+}
+
+// sortedConditionKeys returns condition keys in deterministic order.
+func (d *ClaimDecl) sortedConditionKeys() []string {
+	keys := make([]string, 0, len(d.Conditions))
+	for key := range d.Conditions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
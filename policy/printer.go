@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"are/core"
+)
+
+var claimKeywords = map[core.ClaimType]string{
+	core.Permission:  "PERMIT",
+	core.Prohibition: "PROHIBIT",
+	core.Obligation:  "OBLIGE",
+	core.Delegation:  "DELEGATE",
+}
+
+// Format prints a file in canonical form. Sources and claims keep their
+// declaration order, which the first-applicable strategy decides by. A source is
+// printed just before its first claim, and a claim only gets an UNDER clause when
+// it does not belong to the source printed last. Every claim gets an explicit AS,
+// and relationship and WHERE clauses go on indented continuation lines.
+// Parsing the output yields a file equivalent to the input.
+func Format(f *File) string {
+	var b strings.Builder
+	printed := 0  // sources printed so far
+	current := "" // source claims printed now default to
+	printSourcesThrough := func(last int) {
+		for ; printed <= last; printed++ {
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			formatSource(&b, f.Sources[printed])
+			current = f.Sources[printed].ID
+		}
+	}
+	for _, claim := range f.Claims {
+		for i := printed; i < len(f.Sources); i++ {
+			if f.Sources[i].ID == claim.SourceID {
+				printSourcesThrough(i)
+				break
+			}
+		}
+		formatClaim(&b, claim, claim.SourceID != current)
+	}
+	printSourcesThrough(len(f.Sources) - 1)
+	return b.String()
+}
+
+func formatSource(b *strings.Builder, s *SourceDecl) {
+	b.WriteString("SOURCE ")
+	b.WriteString(quote(s.ID))
+	b.WriteString(" ")
+	b.WriteString(strings.ToUpper(string(s.Type)))
+	if s.Version != "" {
+		b.WriteString(" VERSION ")
+		b.WriteString(quote(s.Version))
+	}
+	if s.Name != "" {
+		b.WriteString(" NAME ")
+		b.WriteString(quoteString(s.Name))
+	}
+	if s.Description != "" {
+		b.WriteString(" DESCRIPTION ")
+		b.WriteString(quoteString(s.Description))
+	}
+	b.WriteString("\n\n")
+}
+
+func formatClaim(b *strings.Builder, c *ClaimDecl, under bool) {
+	b.WriteString(claimKeywords[c.Type])
+	b.WriteString(" ")
+	b.WriteString(quote(c.Subject))
+	b.WriteString(" ")
+	b.WriteString(quote(strings.ToUpper(c.Action)))
+	b.WriteString(" ")
+	b.WriteString(quote(c.Resource))
+
+	if len(c.Jurisdictions) > 0 {
+		b.WriteString(" IN ")
+		b.WriteString(quoteList(c.Jurisdictions))
+	}
+	if len(c.Operations) > 0 {
+		b.WriteString(" FOR ")
+		b.WriteString(quoteList(c.Operations))
+	}
+	if c.TimeStart != nil || c.TimeEnd != nil {
+		b.WriteString(" DURING ")
+		b.WriteString(formatInstant(c.TimeStart))
+		b.WriteString("..")
+		b.WriteString(formatInstant(c.TimeEnd))
+	}
+	if under {
+		b.WriteString(" UNDER SOURCE ")
+		b.WriteString(quote(c.SourceID))
+	}
+	b.WriteString(" AS ")
+	b.WriteString(quote(c.ID))
+	b.WriteString("\n")
+
+	formatRefs(b, "REVOKES", c.Revokes)
+	formatRefs(b, "SUPERSEDES", c.Supersedes)
+	formatRefs(b, "DELEGATES TO", c.DelegatesTo)
+
+	if len(c.Conditions) > 0 {
+		pairs := make([]string, 0, len(c.Conditions))
+		for _, key := range c.sortedConditionKeys() {
+			pairs = append(pairs, quote(key)+" = "+quote(c.Conditions[key]))
+		}
+		b.WriteString("    WHERE ")
+		b.WriteString(strings.Join(pairs, ", "))
+		b.WriteString("\n")
+	}
+}
+
+func formatRefs(b *strings.Builder, clause string, refs []core.ClaimRef) {
+	if len(refs) == 0 {
+		return
+	}
+	values := make([]string, len(refs))
+	for i, ref := range refs {
+		values[i] = ref.String()
+	}
+	b.WriteString("    ")
+	b.WriteString(clause)
+	b.WriteString(" ")
+	b.WriteString(quoteList(values))
+	b.WriteString("\n")
+}
+
+// formatInstant prints midnight UTC as a calendar date and anything else as RFC3339.
+func formatInstant(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	if t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quote(value)
+	}
+	return strings.Join(quoted, ",")
+}
+
+// quote returns value as a bare word when the tokenizer would read it back
+// unchanged, and as a quoted string otherwise.
+func quote(value string) string {
+	if value == "" || strings.HasPrefix(value, "#") || strings.ContainsAny(value, ",=\"") || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return quoteString(value)
+	}
+	return value
+}
+
+// quoteString renders a string literal using only the escapes the tokenizer accepts.
+func quoteString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
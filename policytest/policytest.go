@@ -0,0 +1,352 @@
+// Package policytest runs policy test suites: requests with the decisions a
+// compiled artifact is expected to make for them.
+//
+// A suite is a YAML file (or JSON, a subset of YAML) of the form:
+//
+//	name: engineering access          # optional; defaults to the file name
+//	sources:                          # source files to compile, relative to the suite
+//	  - policy.are
+//	cases:
+//	  - name: interns cannot write in the EU
+//	    request:
+//	      subject: intern
+//	      action: write
+//	      resource: /repos/x
+//	      context:                    # optional; if present, claim scope is enforced
+//	        jurisdiction: EU
+//	        operation: push
+//	        time: 2025-06-01T00:00:00Z
+//	    expect:
+//	      decision: denied            # allowed or denied; required
+//	      authority_id: no_intern_write
+//	      obligations: [log_writes]
+//
+// authority_id and obligations are only checked when present; an empty
+// obligations list expects no obligations. Obligations are compared as sets of
+// claim IDs. Decoding is strict: unknown fields are rejected.
+//
+// Run evaluates every case through core.RuntimeInterface and returns a Report,
+// which Write prints with a readable diff of each failed expectation.
+package policytest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"are/core"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Expected decisions.
+const (
+	Allowed = "allowed"
+	Denied  = "denied"
+)
+
+// Suite is a parsed policy test suite.
+type Suite struct {
+	Name    string   `yaml:"name"`
+	Sources []string `yaml:"sources"`
+	Cases   []Case   `yaml:"cases"`
+
+	// Dir is the directory relative source paths are resolved against.
+	Dir string `yaml:"-"`
+}
+
+// Case is one request and the decision expected for it.
+type Case struct {
+	Name    string      `yaml:"name"`
+	Request Request     `yaml:"request"`
+	Expect  Expectation `yaml:"expect"`
+}
+
+// Request is the request a case decides.
+type Request struct {
+	Subject  string   `yaml:"subject"`
+	Action   string   `yaml:"action"`
+	Resource string   `yaml:"resource"`
+	Context  *Context `yaml:"context"`
+}
+
+// Context is the request context of a case. A zero Time is evaluated at the
+// current time.
+type Context struct {
+	Jurisdiction string    `yaml:"jurisdiction"`
+	Operation    string    `yaml:"operation"`
+	Time         time.Time `yaml:"time"`
+}
+
+func (c *Context) describe() string {
+	parts := []string{}
+	if c.Jurisdiction != "" {
+		parts = append(parts, "jurisdiction "+c.Jurisdiction)
+	}
+	if c.Operation != "" {
+		parts = append(parts, "operation "+c.Operation)
+	}
+	if !c.Time.IsZero() {
+		parts = append(parts, "at "+c.Time.Format(time.RFC3339))
+	}
+	if len(parts) == 0 {
+		return "scope enforced"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Expectation is the outcome expected for a case. Nil fields are not checked.
+type Expectation struct {
+	Decision    string    `yaml:"decision"`
+	AuthorityID *string   `yaml:"authority_id"`
+	Obligations *[]string `yaml:"obligations"`
+}
+
+// ParseFile reads a suite from a file. Sources are resolved relative to it.
+func ParseFile(path string) (*Suite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	suite, err := Parse(path, f)
+	if err != nil {
+		return nil, err
+	}
+	suite.Dir = filepath.Dir(path)
+	return suite, nil
+}
+
+// Parse reads a suite. name is used in error messages and, without its
+// extension, as the suite name if the suite does not set one.
+func Parse(name string, r io.Reader) (*Suite, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	suite := &Suite{}
+	if err := dec.Decode(suite); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if suite.Name == "" {
+		suite.Name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+	if len(suite.Cases) == 0 {
+		return nil, fmt.Errorf("%s: suite has no cases", name)
+	}
+	for i, c := range suite.Cases {
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("%s: case %d (%s): %w", name, i+1, c.Name, err)
+		}
+	}
+	return suite, nil
+}
+
+func (c Case) validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.Request.Subject == "" || c.Request.Action == "" || c.Request.Resource == "" {
+		return errors.New("request subject, action, and resource are required")
+	}
+	if c.Expect.Decision != Allowed && c.Expect.Decision != Denied {
+		return fmt.Errorf("expected decision must be %q or %q, got %q", Allowed, Denied, c.Expect.Decision)
+	}
+	return nil
+}
+
+// SourcePaths returns the suite's source files, resolved against Dir.
+func (s *Suite) SourcePaths() []string {
+	paths := make([]string, len(s.Sources))
+	for i, source := range s.Sources {
+		if filepath.IsAbs(source) {
+			paths[i] = source
+		} else {
+			paths[i] = filepath.Join(s.Dir, source)
+		}
+	}
+	return paths
+}
+
+// authorizationRequest converts the request of a case to the runtime's form.
+func (r Request) authorizationRequest() core.AuthorizationRequest {
+	req := core.AuthorizationRequest{Subject: r.Subject, Action: r.Action, Resource: r.Resource}
+	if r.Context != nil {
+		req.Context = &core.RequestContext{
+			Jurisdiction: r.Context.Jurisdiction,
+			Operation:    r.Context.Operation,
+			Time:         r.Context.Time,
+		}
+	}
+	return req
+}
+
+// Mismatch is an expectation a case did not meet. Detail, if set, spells out the
+// difference between Want and Got.
+type Mismatch struct {
+	Field  string
+	Want   string
+	Got    string
+	Detail string
+}
+
+// CaseResult is the outcome of one case.
+type CaseResult struct {
+	Case        Case
+	Result      core.AuthorizationResult
+	Obligations []string
+	Mismatches  []Mismatch
+}
+
+// Passed reports whether the case met all its expectations.
+func (r CaseResult) Passed() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Report is the outcome of running a suite.
+type Report struct {
+	Suite      string
+	ArtifactID string
+	Results    []CaseResult
+}
+
+// Failed returns the number of failed cases.
+func (r Report) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Run evaluates every case of the suite against runtime, in order.
+func (s *Suite) Run(runtime *core.RuntimeInterface) Report {
+	report := Report{Suite: s.Name, ArtifactID: runtime.GetArtifact().ID}
+	for _, c := range s.Cases {
+		report.Results = append(report.Results, runCase(runtime, c))
+	}
+	return report
+}
+
+func runCase(runtime *core.RuntimeInterface, c Case) CaseResult {
+	req := c.Request.authorizationRequest()
+	decided, obligations := runtime.AuthorizeWithObligations(req)
+	result := CaseResult{Case: c, Result: decided, Obligations: []string{}}
+	for _, obligation := range obligations {
+		result.Obligations = append(result.Obligations, obligation.ID)
+	}
+	sort.Strings(result.Obligations)
+
+	if got := decision(result.Result.Allowed); got != c.Expect.Decision {
+		result.Mismatches = append(result.Mismatches, Mismatch{Field: "decision", Want: c.Expect.Decision, Got: got})
+	}
+	if want := c.Expect.AuthorityID; want != nil {
+		got := ""
+		if result.Result.Proof.Claim != nil {
+			got = result.Result.AuthorityID
+		}
+		if got != *want {
+			result.Mismatches = append(result.Mismatches, Mismatch{Field: "authority_id", Want: orNone(*want), Got: orNone(got)})
+		}
+	}
+	if c.Expect.Obligations != nil {
+		want := append([]string{}, *c.Expect.Obligations...)
+		sort.Strings(want)
+		if missing, unexpected := difference(want, result.Obligations), difference(result.Obligations, want); len(missing)+len(unexpected) > 0 {
+			details := []string{}
+			if len(missing) > 0 {
+				details = append(details, "missing "+strings.Join(missing, ", "))
+			}
+			if len(unexpected) > 0 {
+				details = append(details, "unexpected "+strings.Join(unexpected, ", "))
+			}
+			result.Mismatches = append(result.Mismatches, Mismatch{
+				Field:  "obligations",
+				Want:   listOrNone(want),
+				Got:    listOrNone(result.Obligations),
+				Detail: strings.Join(details, "; "),
+			})
+		}
+	}
+	return result
+}
+
+func decision(allowed bool) string {
+	if allowed {
+		return Allowed
+	}
+	return Denied
+}
+
+// difference returns the values of a that are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, value := range b {
+		in[value] = true
+	}
+	out := []string{}
+	for _, value := range a {
+		if !in[value] {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+func listOrNone(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// Write prints the report: a line per failed case followed by what was expected
+// and what was decided, then a summary. With verbose, passing cases are listed too.
+func (r Report) Write(w io.Writer, verbose bool) {
+	for _, result := range r.Results {
+		if result.Passed() {
+			if verbose {
+				fmt.Fprintf(w, "--- PASS: %s / %s\n", r.Suite, result.Case.Name)
+			}
+			continue
+		}
+		req := result.Case.Request
+		fmt.Fprintf(w, "--- FAIL: %s / %s\n", r.Suite, result.Case.Name)
+		fmt.Fprintf(w, "    request: %s %s %s", req.Subject, req.Action, req.Resource)
+		if rc := req.Context; rc != nil {
+			fmt.Fprintf(w, " (%s)", rc.describe())
+		}
+		fmt.Fprintln(w)
+		for _, m := range result.Mismatches {
+			fmt.Fprintf(w, "    %s:\n", m.Field)
+			fmt.Fprintf(w, "      - want %s\n", m.Want)
+			fmt.Fprintf(w, "      + got  %s\n", m.Got)
+			if m.Detail != "" {
+				fmt.Fprintf(w, "      (%s)\n", m.Detail)
+			}
+		}
+		fmt.Fprintf(w, "    reason: %s\n", result.Result.Reason)
+	}
+	status := "ok"
+	if r.Failed() > 0 {
+		status = "FAIL"
+	}
+	fmt.Fprintf(w, "%s\t%s\t%d/%d cases passed (artifact %s)\n", status, r.Suite, len(r.Results)-r.Failed(), len(r.Results), r.ArtifactID)
+}
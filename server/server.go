@@ -0,0 +1,186 @@
+// Package server serves authorization decisions from a core.RuntimeInterface as a
+// JSON HTTP API, so that services not written in Go can act as policy
+// enforcement points.
+//
+// Every query endpoint accepts POST with a core.AuthorizationRequest body:
+//
+//	{"subject": "intern", "action": "write", "resource": "/repos/x",
+//	 "context": {"jurisdiction": "EU", "operation": "push", "time": "2025-06-01T00:00:00Z"}}
+//
+// "context" is optional; without it claim scope is not consulted.
+//
+//	POST /v1/authorize        -> core.AuthorizationResult
+//	POST /v1/authorize/batch  {"requests": [...]} -> {"results": [...]}
+//	POST /v1/obligations      -> {"obligations": [claim, ...]}
+//	POST /v1/authority-info   -> core.AuthorityInfo
+//	GET  /healthz             -> 200 while the process is serving
+//	GET  /readyz              -> 200 while ready for traffic, 503 otherwise
+//
+// Malformed requests are answered with 400 and {"error": "..."}. A request that
+// is well formed but matches no authority is a normal, denied decision.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"are/core"
+)
+
+// maxRequestBytes bounds request bodies; a batch of a few thousand requests fits.
+const maxRequestBytes = 1 << 20
+
+// BatchRequest is the body of /v1/authorize/batch.
+type BatchRequest struct {
+	Requests []core.AuthorizationRequest `json:"requests"`
+}
+
+// BatchResponse is the response of /v1/authorize/batch, in request order.
+type BatchResponse struct {
+	Results []core.AuthorizationResult `json:"results"`
+}
+
+// ObligationsResponse is the response of /v1/obligations.
+type ObligationsResponse struct {
+	Obligations []core.Claim `json:"obligations"`
+}
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server is an http.Handler answering queries against a RuntimeInterface.
+// It is ready for traffic as soon as it is created; SetReady(false) takes it out
+// of rotation, e.g. while shutting down.
+type Server struct {
+	runtime *core.RuntimeInterface
+	mux     *http.ServeMux
+	ready   atomic.Bool
+}
+
+// New creates a server for the runtime interface.
+func New(runtime *core.RuntimeInterface) *Server {
+	s := &Server{runtime: runtime, mux: http.NewServeMux()}
+	s.ready.Store(true)
+	s.mux.HandleFunc("/v1/authorize", s.handleAuthorize)
+	s.mux.HandleFunc("/v1/authorize/batch", s.handleBatch)
+	s.mux.HandleFunc("/v1/obligations", s.handleObligations)
+	s.mux.HandleFunc("/v1/authority-info", s.handleAuthorityInfo)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	return s
+}
+
+// SetReady sets whether /readyz reports the server as ready for traffic.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	var req core.AuthorizationRequest
+	if !decodeRequest(w, r, &req) || !validRequest(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.runtime.Authorize(req))
+}
+
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var batch BatchRequest
+	if !decodeRequest(w, r, &batch) {
+		return
+	}
+	for _, req := range batch.Requests {
+		if !validRequest(w, req) {
+			return
+		}
+	}
+	results := s.runtime.AuthorizeBatch(batch.Requests)
+	writeJSON(w, http.StatusOK, BatchResponse{Results: results})
+}
+
+func (s *Server) handleObligations(w http.ResponseWriter, r *http.Request) {
+	var req core.AuthorizationRequest
+	if !decodeRequest(w, r, &req) || !validRequest(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, ObligationsResponse{Obligations: s.runtime.ObligationsFor(req)})
+}
+
+func (s *Server) handleAuthorityInfo(w http.ResponseWriter, r *http.Request) {
+	var req core.AuthorizationRequest
+	if !decodeRequest(w, r, &req) || !validRequest(w, req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.runtime.AuthorityInfoFor(req))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"status": "not ready"})
+		return
+	}
+	snapshot := s.runtime.Snapshot()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":      "ready",
+		"artifact_id": snapshot.ArtifactID,
+		"generation":  snapshot.Generation,
+	})
+}
+
+// decodeRequest decodes a POSTed JSON body into v, answering the request itself
+// if that fails.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+		return false
+	}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return false
+		}
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	if decoder.Decode(&struct{}{}) != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body: trailing data")
+		return false
+	}
+	return true
+}
+
+func validRequest(w http.ResponseWriter, req core.AuthorizationRequest) bool {
+	if req.Subject == "" || req.Action == "" || req.Resource == "" {
+		writeError(w, http.StatusBadRequest, "subject, action, and resource are required")
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ErrorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,304 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"are/cli"
+	"are/core"
+)
+
+// writePolicy writes policy text to a file in a fresh temporary directory.
+func writePolicy(t *testing.T, name, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCompileCommandWritesSignedArtifactAndProof(t *testing.T) {
+	src := writePolicy(t, "policy.are", `SOURCE company_policy ORGANIZATIONAL VERSION 1.0.0
+PERMIT engineer READ /repos/* AS eng_read
+SOURCE security_baseline REGULATORY VERSION 1.0.0
+PROHIBIT engineer READ /repos/secret AS no_secret
+`)
+	dir := filepath.Dir(src)
+	signer, err := core.GenerateEd25519Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := signer.WritePrivateKey(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	artifactPath := filepath.Join(dir, "out", "artifact.json")
+	proofPath := filepath.Join(dir, "out", "proof.json")
+
+	code, stdout, stderr := runCLI("compile", "-o", artifactPath, "-proof", proofPath,
+		"-sign", keyPath, "-generated-at", "2025-01-01T00:00:00Z", src)
+	if code != cli.ExitOK {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	artifact, sig, err := core.ReadArtifactFile(artifactPath)
+	if err != nil {
+		t.Fatalf("ReadArtifactFile: %v", err)
+	}
+	if !strings.Contains(stdout, artifact.ID) || len(artifact.Claims) != 2 {
+		t.Errorf("unexpected artifact %s with %d claims; output %q", artifact.ID, len(artifact.Claims), stdout)
+	}
+	if sig == nil {
+		t.Fatal("expected the artifact file to carry a signature")
+	}
+	if err := core.VerifyArtifact(core.NewKeyRing(signer.PublicKey()), artifact, *sig); err != nil {
+		t.Errorf("artifact signature does not verify: %v", err)
+	}
+	if _, err := os.Stat(proofPath); err != nil {
+		t.Errorf("proof not written: %v", err)
+	}
+	if _, err := os.Stat(proofPath + ".sig"); err != nil {
+		t.Errorf("proof signature not written: %v", err)
+	}
+}
+
+func TestCompileCommandReportsFailure(t *testing.T) {
+	src := writePolicy(t, "policy.are", `SOURCE policy_a ORGANIZATIONAL VERSION 1.0.0
+PERMIT analyst READ /reports/* AS read_business_hours WHERE hours = "09-17"
+SOURCE policy_b ORGANIZATIONAL VERSION 1.0.0
+PERMIT analyst READ /reports/* AS read_anytime WHERE hours = "00-24"
+`)
+	artifactPath := filepath.Join(filepath.Dir(src), "artifact.json")
+
+	code, _, stderr := runCLI("compile", "-o", artifactPath, src)
+	if code != cli.ExitFailure {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	for _, want := range []string{"stage resolution", "ambiguous", "read_business_hours", "read_anytime"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected %q in the failure report:\n%s", want, stderr)
+		}
+	}
+	if _, err := os.Stat(artifactPath); !os.IsNotExist(err) {
+		t.Errorf("expected no artifact to be written, got %v", err)
+	}
+}
+
+func TestCLIUsageErrors(t *testing.T) {
+	for _, args := range [][]string{nil, {"frobnicate"}, {"compile"}, {"serve"}, {"check", "-subject", "intern"}} {
+		if code, _, _ := runCLI(args...); code != cli.ExitUsage {
+			t.Errorf("are %v: expected exit 2, got %d", args, code)
+		}
+	}
+}
+
+// compiledPolicy compiles policy text with "are compile" and returns the artifact path.
+func compiledPolicy(t *testing.T, text string) string {
+	t.Helper()
+	src := writePolicy(t, "policy.are", text)
+	artifactPath := filepath.Join(filepath.Dir(src), "artifact.json")
+	proofPath := filepath.Join(filepath.Dir(src), "proof.json")
+	if code, _, stderr := runCLI("compile", "-o", artifactPath, "-proof", proofPath, src); code != cli.ExitOK {
+		t.Fatalf("compile failed with exit %d: %s", code, stderr)
+	}
+	return artifactPath
+}
+
+const explainPolicy = `SOURCE law LEGAL VERSION 1.0.0 NAME "Data Law"
+SOURCE company ORGANIZATIONAL VERSION 2.0.0 NAME "Company Policy"
+PROHIBIT intern WRITE /repos/* IN US,EU UNDER law AS no_intern_write
+PERMIT intern WRITE /repos/* UNDER company AS intern_write
+OBLIGE intern WRITE /repos/* UNDER company AS log_writes
+`
+
+func TestCheckCommandReportsDecision(t *testing.T) {
+	artifactPath := compiledPolicy(t, explainPolicy)
+	cases := []struct {
+		jurisdiction string
+		code         int
+		authority    string
+	}{
+		{"EU", cli.ExitDenied, "no_intern_write"},
+		{"JP", cli.ExitOK, "intern_write"},
+	}
+	for _, tc := range cases {
+		code, stdout, stderr := runCLI("check", "-artifact", artifactPath,
+			"-subject", "intern", "-action", "write", "-resource", "/repos/x", "-jurisdiction", tc.jurisdiction, "-json")
+		if code != tc.code {
+			t.Errorf("%s: expected exit %d, got %d: %s", tc.jurisdiction, tc.code, code, stderr)
+			continue
+		}
+		var result core.AuthorizationResult
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("%s: decoding result: %v", tc.jurisdiction, err)
+		}
+		if result.AuthorityID != tc.authority {
+			t.Errorf("%s: expected %s to decide, got %+v", tc.jurisdiction, tc.authority, result)
+		}
+	}
+}
+
+func TestExplainCommandPrintsReasoning(t *testing.T) {
+	artifactPath := compiledPolicy(t, explainPolicy)
+	code, stdout, stderr := runCLI("explain", "-artifact", artifactPath,
+		"-subject", "intern", "-action", "write", "-resource", "/repos/x", "-jurisdiction", "EU")
+	if code != cli.ExitOK {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	for _, want := range []string{
+		"decision:   denied (prohibited)",
+		"deciding claim no_intern_write (prohibition)",
+		"resource  /repos/* matches /repos/x",
+		"scope     in US,EU, any operation, any time",
+		"source    law, legal, version 1.0.0, precedence rank 1",
+		"matched claims (3)",
+		"intern_write permission, source company: overridden by higher-precedence claim law:no_intern_write",
+		"log_writes: intern write /repos/*",
+		"company organizational, version 2.0.0: Company Policy",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in the explanation:\n%s", want, stdout)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to write from one goroutine and read from another.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// eventually polls cond until it holds, failing the test after a few seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func TestServeCommandReloadsOnHangup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not available on Windows")
+	}
+	dir := t.TempDir()
+	signer, err := core.GenerateEd25519Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath, pubPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	if err := signer.WritePrivateKey(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.WritePublicKey(pubPath); err != nil {
+		t.Fatal(err)
+	}
+	artifactPath := filepath.Join(dir, "artifact.json")
+	compile := func(text string, args ...string) string {
+		t.Helper()
+		src := filepath.Join(dir, "policy.are")
+		if err := os.WriteFile(src, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if code, _, stderr := runCLI(append(append([]string{"compile", "-o", artifactPath, "-proof", filepath.Join(dir, "proof.json")}, args...), src)...); code != cli.ExitOK {
+			t.Fatalf("compile failed with exit %d: %s", code, stderr)
+		}
+		artifact, _, err := core.ReadArtifactFile(artifactPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return artifact.ID
+	}
+	first := compile("SOURCE policy ORGANIZATIONAL VERSION 1.0.0\nPERMIT engineer READ /repos/* AS eng_read\n", "-sign", keyPath)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+	var logs syncBuffer
+	done := make(chan int, 1)
+	go func() {
+		done <- cli.Run([]string{"serve", "-addr", addr, "-artifact", artifactPath, "-keys", pubPath}, &logs, &logs)
+	}()
+
+	ready := func() (id string, generation uint64) {
+		resp, err := http.Get("http://" + addr + "/readyz")
+		if err != nil {
+			return "", 0
+		}
+		defer resp.Body.Close()
+		var body struct {
+			ArtifactID string `json:"artifact_id"`
+			Generation uint64 `json:"generation"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return body.ArtifactID, body.Generation
+	}
+	signal := func(sig os.Signal) {
+		t.Helper()
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := process.Signal(sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventually(t, "the server to start", func() bool { id, _ := ready(); return id == first })
+
+	// An unsigned artifact fails the -keys check and leaves the first one in force.
+	compile("SOURCE policy ORGANIZATIONAL VERSION 1.0.0\nPERMIT engineer WRITE /repos/* AS eng_write\n")
+	signal(syscall.SIGHUP)
+	eventually(t, "the reload to fail", func() bool { return strings.Contains(logs.String(), "Reload failed") })
+	if id, generation := ready(); id != first || generation != 1 {
+		t.Fatalf("expected generation 1 of %s to stay in force, got generation %d of %s", first, generation, id)
+	}
+
+	second := compile("SOURCE policy ORGANIZATIONAL VERSION 1.0.0\nPERMIT engineer WRITE /repos/* AS eng_write\n", "-sign", keyPath)
+	signal(syscall.SIGHUP)
+	eventually(t, "the signed artifact to be swapped in", func() bool {
+		id, generation := ready()
+		return id == second && generation == 2
+	})
+
+	signal(syscall.SIGTERM)
+	select {
+	case code := <-done:
+		if code != cli.ExitOK {
+			t.Errorf("expected exit 0, got %d:\n%s", code, logs.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
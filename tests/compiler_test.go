@@ -2,8 +2,12 @@ package tests
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"are/core"
 )
@@ -155,6 +159,7 @@ func TestFullCompilationWithConflicts(t *testing.T) {
 		Description: "For testing purposes",
 		Version:     "1.0",
 		Metadata: map[string]interface{}{
+			core.StrategyMetadataKey: core.StrategyDenyOverrides,
 			"claims": []interface{}{
 				map[string]interface{}{
 					"id":       "claim_permission",
@@ -196,7 +201,7 @@ func TestFullCompilationWithConflicts(t *testing.T) {
 	}
 }
 
-func TestSamePrecedenceProhibitionWinsConflict(t *testing.T) {
+func TestSamePrecedencePermissionAndProhibitionFailClosed(t *testing.T) {
 	compiler := core.NewAuthorityCompiler()
 	source := core.AuthoritySource{
 		ID:      "test_source",
@@ -226,18 +231,78 @@ func TestSamePrecedenceProhibitionWinsConflict(t *testing.T) {
 	}
 
 	result := compiler.Process(source)
-	success, ok := result.(core.CompilationSuccess)
+	failure, ok := result.(core.CompilationFailure)
 	if !ok {
-		t.Fatalf("expected CompilationSuccess, got %T", result)
+		t.Fatalf("expected CompilationFailure, got %T", result)
+	}
+	if failure.FailureStage != "resolution" || !failure.FailClosed || len(failure.InvolvedClaimIDs) != 2 {
+		t.Errorf("expected a closed resolution failure naming both claims, got %+v", failure)
 	}
+}
 
-	runtime := core.NewRuntimeInterface(success.Artifact)
-	authResult := runtime.IsAuthorized("user_1", "read", "/data/secret.txt")
-	if authResult["allowed"].(bool) {
-		t.Fatal("same-precedence prohibition must deny over permission")
+func TestEqualPrecedenceClaimsWithDifferentConditionsAreAmbiguous(t *testing.T) {
+	business := testClaim("read_business_hours", "permission", "analyst", "read", "/reports/*")
+	business["conditions"] = map[string]interface{}{"hours": "09-17"}
+	anytime := testClaim("read_anytime", "permission", "analyst", "read", "/reports/*")
+	anytime["conditions"] = map[string]interface{}{"hours": "00-24"}
+
+	compiler := core.NewAuthorityCompiler()
+	artifact, err := compiler.NormalizeSources(context.Background(), []core.AuthoritySource{
+		testSource("policy_a", core.Organizational, business),
+		testSource("policy_b", core.Organizational, anytime),
+	})
+	if err != nil {
+		t.Fatalf("NormalizeSources: %v", err)
 	}
-	if authResult["authority_id"].(string) != "deny_read" {
-		t.Fatalf("expected deny_read to be authoritative, got %v", authResult["authority_id"])
+	_, err = compiler.ResolveConflicts(context.Background(), artifact)
+	var conflict *core.ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, core.ErrAmbiguousConflict) {
+		t.Fatalf("expected ambiguous ConflictError, got %v", err)
+	}
+	if len(conflict.ClaimIDs) != 2 {
+		t.Errorf("expected both claims to be listed, got %v", conflict.ClaimIDs)
+	}
+}
+
+func TestEqualPrecedencePermissionAndProhibitionAreAmbiguous(t *testing.T) {
+	sources := []core.AuthoritySource{
+		testSource("policy_a", core.Organizational,
+			testClaim("read_reports", "permission", "analyst", "read", "/reports/*")),
+		testSource("policy_b", core.Organizational,
+			testClaim("no_report_reads", "prohibition", "analyst", "read", "/reports/*")),
+	}
+	compiler := core.NewAuthorityCompiler()
+	artifact, err := compiler.NormalizeSources(context.Background(), sources)
+	if err != nil {
+		t.Fatalf("NormalizeSources: %v", err)
+	}
+	_, err = compiler.ResolveConflicts(context.Background(), artifact)
+	var conflict *core.ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, core.ErrAmbiguousConflict) {
+		t.Fatalf("expected ambiguous ConflictError, got %v", err)
+	}
+	if len(conflict.ClaimIDs) != 2 {
+		t.Errorf("expected both claims to be listed, got %v", conflict.ClaimIDs)
+	}
+
+	// A strategy that decides by effect settles the tie.
+	artifact = compileSources(t, withStrategy(sources[0], core.StrategyDenyOverrides), sources[1])
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorized("analyst", "read", "/reports/q3"); result["allowed"] != false {
+		t.Errorf("expected deny-overrides to let the prohibition win, got %v", result["reason"])
+	}
+}
+
+func TestEqualPrecedenceEquivalentClaimsAreNotAmbiguous(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("policy_a", core.Organizational,
+			testClaim("read_reports", "permission", "analyst", "read", "/reports/*")),
+		testSource("policy_b", core.Organizational,
+			testClaim("read_reports_too", "permission", "analyst", "read", "/reports/*")),
+	)
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorized("analyst", "read", "/reports/q3"); result["allowed"] != true {
+		t.Errorf("expected equivalent permissions to allow, got %v", result["reason"])
 	}
 }
 
@@ -465,3 +530,718 @@ func TestVersionParsing(t *testing.T) {
 		t.Error("Version parsing should not cause failure")
 	}
 }
+
+func multiSourceFixture() []core.AuthoritySource {
+	return []core.AuthoritySource{
+		{
+			ID:      "company_policy",
+			Type:    core.Organizational,
+			Name:    "Company Policy",
+			Version: "3.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "export_allowed",
+						"type":     "permission",
+						"subject":  "analyst",
+						"action":   "export",
+						"resource": "/customers",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:      "gdpr",
+			Type:    core.Regulatory,
+			Name:    "GDPR",
+			Version: "1.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "export_restricted",
+						"type":     "prohibition",
+						"subject":  "analyst",
+						"action":   "export",
+						"resource": "/customers",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:      "vendor_contract",
+			Type:    core.Contractual,
+			Name:    "Vendor Contract",
+			Version: "1.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "vendor_read",
+						"type":     "permission",
+						"subject":  "vendor",
+						"action":   "read",
+						"resource": "/invoices",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMultiSourceCompilationResolvesAcrossSources(t *testing.T) {
+	compiler := core.NewAuthorityCompiler()
+	result := compiler.ProcessSources(multiSourceFixture())
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected CompilationSuccess, got %#v", result)
+	}
+
+	artifact := success.Artifact
+	if len(artifact.Sources) != 3 {
+		t.Fatalf("expected 3 recorded sources, got %d", len(artifact.Sources))
+	}
+	if artifact.SourceID != "" {
+		t.Errorf("multi-source artifact should not claim a single source, got %q", artifact.SourceID)
+	}
+
+	sourceOf := make(map[string]string)
+	for _, claim := range artifact.Claims {
+		sourceOf[claim.ID] = claim.SourceID
+	}
+	if _, ok := sourceOf["export_allowed"]; ok {
+		t.Error("organizational permission should lose to regulatory prohibition")
+	}
+	if sourceOf["export_restricted"] != "gdpr" {
+		t.Errorf("expected export_restricted to keep source gdpr, got %q", sourceOf["export_restricted"])
+	}
+	if sourceOf["vendor_read"] != "vendor_contract" {
+		t.Errorf("expected vendor_read to keep source vendor_contract, got %q", sourceOf["vendor_read"])
+	}
+}
+
+func TestMultiSourceHigherAuthorityPermissionWins(t *testing.T) {
+	sources := multiSourceFixture()
+	sources[0].Type = core.Sovereign
+
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected CompilationSuccess, got %#v", result)
+	}
+
+	runtime := core.NewRuntimeInterface(success.Artifact)
+	authResult := runtime.IsAuthorized("analyst", "export", "/customers")
+	if !authResult["allowed"].(bool) || authResult["authority_id"] != "export_allowed" {
+		t.Fatalf("sovereign permission should beat regulatory prohibition, got %v", authResult)
+	}
+}
+
+func TestMultiSourceRejectsDuplicateSourceIDs(t *testing.T) {
+	sources := multiSourceFixture()
+	sources[1].ID = sources[0].ID
+
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	failure, ok := result.(core.CompilationFailure)
+	if !ok {
+		t.Fatalf("expected CompilationFailure, got %T", result)
+	}
+	if failure.FailureStage != "normalization" {
+		t.Errorf("expected normalization failure, got %s", failure.FailureStage)
+	}
+}
+
+func TestSourcesMayShareClaimIDs(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("gdpr", core.Regulatory,
+			testClaim("c1", "prohibition", "analyst", "export", "/customers")),
+		testSource("org", core.Organizational,
+			testClaim("c1", "permission", "analyst", "read", "/customers")),
+	)
+
+	if len(artifact.Claims) != 2 {
+		t.Fatalf("expected both c1 claims to survive, got %+v", artifact.Claims)
+	}
+	for _, id := range []string{"gdpr:c1", "org:c1"} {
+		if _, ok := artifact.Graph.Nodes[id]; !ok {
+			t.Errorf("expected graph node %s, got %v", id, artifact.Graph.Nodes)
+		}
+	}
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.Authorize(core.AuthorizationRequest{Subject: "analyst", Action: "read", Resource: "/customers"}); !result.Allowed || result.Proof.Claim.SourceID != "org" {
+		t.Errorf("expected org:c1 to permit reads, got %+v", result)
+	}
+	if result := runtime.Authorize(core.AuthorizationRequest{Subject: "analyst", Action: "export", Resource: "/customers"}); result.Allowed || result.Proof.Claim.SourceID != "gdpr" {
+		t.Errorf("expected gdpr:c1 to prohibit exports, got %+v", result)
+	}
+
+	// IDs must still be unique within a source.
+	result := core.NewAuthorityCompiler().ProcessSources([]core.AuthoritySource{
+		testSource("org", core.Organizational,
+			testClaim("c1", "permission", "analyst", "read", "/customers"),
+			testClaim("c1", "permission", "analyst", "write", "/customers")),
+	})
+	if _, ok := result.(core.CompilationFailure); !ok {
+		t.Fatalf("expected CompilationFailure for a duplicate ID within one source, got %T", result)
+	}
+}
+
+func TestMultiSourceRequiresSources(t *testing.T) {
+	result := core.NewAuthorityCompiler().ProcessSources(nil)
+	if _, ok := result.(core.CompilationFailure); !ok {
+		t.Fatalf("expected CompilationFailure for empty source set, got %T", result)
+	}
+}
+
+func amendmentSources(revokes interface{}) []core.AuthoritySource {
+	return []core.AuthoritySource{
+		{
+			ID:      "company_policy",
+			Type:    core.Organizational,
+			Name:    "Company Policy",
+			Version: "1.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "retain_logs",
+						"type":     "permission",
+						"subject":  "ops",
+						"action":   "retain",
+						"resource": "/logs/*",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:      "statute",
+			Type:    core.Legal,
+			Name:    "Data Retention Act",
+			Version: "2.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "retention_limit",
+						"type":     "obligation",
+						"subject":  "ops",
+						"action":   "purge",
+						"resource": "/logs/*",
+						"scope":    map[string]interface{}{},
+						"conditions": map[string]interface{}{
+							"revokes": revokes,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestQualifiedReferenceRevokesClaimInOtherSource(t *testing.T) {
+	refs := []interface{}{
+		"company_policy:retain_logs",
+		map[string]interface{}{"source_id": "company_policy", "claim_id": "retain_logs"},
+	}
+	for _, ref := range refs {
+		result := core.NewAuthorityCompiler().ProcessSources(amendmentSources(ref))
+		success, ok := result.(core.CompilationSuccess)
+		if !ok {
+			t.Fatalf("expected CompilationSuccess for %v, got %#v", ref, result)
+		}
+		for _, claim := range success.Artifact.Claims {
+			if claim.ID == "retain_logs" {
+				t.Errorf("retain_logs should be revoked by statute via %v", ref)
+			}
+		}
+	}
+}
+
+func TestQualifiedReferenceDisambiguatesSharedClaimIDs(t *testing.T) {
+	revoke := func(id string, ref interface{}) map[string]interface{} {
+		claim := testClaim(id, "obligation", "ops", "purge", "/logs/*")
+		claim["conditions"] = map[string]interface{}{"revokes": ref}
+		return claim
+	}
+	cases := []struct {
+		name    string
+		sources []core.AuthoritySource
+		revoked string
+		by      string
+	}{
+		{
+			name: "qualified",
+			sources: []core.AuthoritySource{
+				testSource("gdpr", core.Regulatory, testClaim("c1", "permission", "ops", "retain", "/logs/*")),
+				testSource("org", core.Organizational, testClaim("c1", "permission", "ops", "retain", "/logs/*")),
+				testSource("statute", core.Legal, revoke("limit", "org:c1")),
+			},
+			revoked: "org:c1",
+			by:      "statute:limit",
+		},
+		{
+			name: "unqualified within own source",
+			sources: []core.AuthoritySource{
+				testSource("gdpr", core.Regulatory, testClaim("c1", "permission", "ops", "retain", "/logs/*")),
+				testSource("org", core.Organizational,
+					testClaim("c1", "permission", "ops", "retain", "/logs/*"),
+					revoke("cleanup", "c1")),
+			},
+			revoked: "org:c1",
+			by:      "org:cleanup",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact := compileSources(t, tc.sources...)
+			want := []core.ResolutionRecord{{ClaimID: tc.revoked, By: tc.by, Edge: core.Revokes}}
+			if !reflect.DeepEqual(artifact.Resolutions, want) {
+				t.Fatalf("expected %+v, got %+v", want, artifact.Resolutions)
+			}
+			if _, ok := artifact.Graph.Nodes["gdpr:c1"]; !ok {
+				t.Errorf("gdpr:c1 should survive, got %v", artifact.Graph.Nodes)
+			}
+			if _, ok := artifact.Graph.Nodes[tc.revoked]; ok {
+				t.Errorf("%s should be revoked", tc.revoked)
+			}
+		})
+	}
+}
+
+func TestRevocationIsRecordedInResolutions(t *testing.T) {
+	result := core.NewAuthorityCompiler().ProcessSources(amendmentSources("company_policy:retain_logs"))
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected CompilationSuccess, got %#v", result)
+	}
+	want := core.ResolutionRecord{ClaimID: "company_policy:retain_logs", By: "statute:retention_limit", Edge: core.Revokes}
+	if len(success.Artifact.Resolutions) != 1 || !reflect.DeepEqual(success.Artifact.Resolutions[0], want) {
+		t.Fatalf("expected %+v, got %+v", want, success.Artifact.Resolutions)
+	}
+	if !strings.Contains(success.Proof, `"resolutions"`) || !strings.Contains(success.Proof, `"retention_limit"`) {
+		t.Errorf("proof should record the revocation:\n%s", success.Proof)
+	}
+}
+
+func TestUnresolvedReferenceFailsClosed(t *testing.T) {
+	refs := []interface{}{
+		"retain_logs",                // unqualified: resolves only within statute
+		"company_policy:missing",     // qualified: claim does not exist
+		"unknown_source:retain_logs", // qualified: source does not exist
+	}
+	for _, ref := range refs {
+		result := core.NewAuthorityCompiler().ProcessSources(amendmentSources(ref))
+		failure, ok := result.(core.CompilationFailure)
+		if !ok {
+			t.Fatalf("expected CompilationFailure for %v, got %T", ref, result)
+		}
+		if len(failure.InvolvedClaimIDs) != 1 || failure.InvolvedClaimIDs[0] != "statute:retention_limit" {
+			t.Errorf("expected retention_limit to be reported for %v, got %v", ref, failure.InvolvedClaimIDs)
+		}
+	}
+}
+
+func TestClaimIDsCannotContainColons(t *testing.T) {
+	result := core.NewAuthorityCompiler().ProcessSources([]core.AuthoritySource{
+		testSource("company_policy", core.Organizational,
+			testClaim("logs:retain", "permission", "ops", "retain", "/logs/*")),
+	})
+	failure, ok := result.(core.CompilationFailure)
+	if !ok {
+		t.Fatalf("expected CompilationFailure, got %T", result)
+	}
+	if !strings.Contains(failure.ViolatedInvariant, "logs:retain contains ':'") {
+		t.Errorf("expected the failure to name the claim ID, got %q", failure.ViolatedInvariant)
+	}
+
+	ref, err := core.ParseClaimRef("eu:company_policy:retain_logs")
+	if err != nil || ref != (core.ClaimRef{SourceID: "eu:company_policy", ClaimID: "retain_logs"}) {
+		t.Errorf("expected the reference to split at its last ':', got %+v, %v", ref, err)
+	}
+}
+
+func TestLowerAuthorityCannotRevokeHigherAuthority(t *testing.T) {
+	sources := amendmentSources("company_policy:retain_logs")
+	sources[1].Type = core.Contractual
+
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	if _, ok := result.(core.CompilationFailure); !ok {
+		t.Fatalf("contractual source must not revoke organizational claim, got %T", result)
+	}
+}
+
+func TestPinnedClockBuildsAreReproducible(t *testing.T) {
+	pinned := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	build := func() core.CompilationSuccess {
+		compiler := core.NewAuthorityCompiler()
+		compiler.SetClock(func() time.Time { return pinned })
+		success, ok := compiler.ProcessSources(multiSourceFixture()).(core.CompilationSuccess)
+		if !ok {
+			t.Fatal("expected CompilationSuccess")
+		}
+		return success
+	}
+
+	first, second := build(), build()
+	if first.Proof != second.Proof {
+		t.Errorf("proofs differ between builds:\n%s\n%s", first.Proof, second.Proof)
+	}
+	a, err := core.CanonicalArtifact(first.Artifact)
+	if err != nil {
+		t.Fatalf("CanonicalArtifact: %v", err)
+	}
+	b, err := core.CanonicalArtifact(second.Artifact)
+	if err != nil {
+		t.Fatalf("CanonicalArtifact: %v", err)
+	}
+	if string(a) != string(b) {
+		t.Errorf("artifacts differ between builds")
+	}
+	if !strings.HasPrefix(first.Artifact.ID, "sha256:") {
+		t.Errorf("expected content-addressed ID, got %s", first.Artifact.ID)
+	}
+	if !first.Artifact.GeneratedAt.Equal(pinned) {
+		t.Errorf("GeneratedAt = %v, want %v", first.Artifact.GeneratedAt, pinned)
+	}
+}
+
+func TestArtifactIDTracksContentNotTime(t *testing.T) {
+	compile := func(sources []core.AuthoritySource, at time.Time) core.AuthorityArtifact {
+		compiler := core.NewAuthorityCompiler()
+		compiler.SetClock(func() time.Time { return at })
+		success, ok := compiler.ProcessSources(sources).(core.CompilationSuccess)
+		if !ok {
+			t.Fatal("expected CompilationSuccess")
+		}
+		return success.Artifact
+	}
+
+	base := compile(multiSourceFixture(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := compile(multiSourceFixture(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if base.ID != later.ID {
+		t.Errorf("ID changed with compile time: %s vs %s", base.ID, later.ID)
+	}
+
+	bumped := multiSourceFixture()
+	bumped[0].Version = "9.9.9"
+	if other := compile(bumped, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); other.ID == base.ID {
+		t.Error("ID did not change when a source version changed")
+	}
+}
+
+// testSource builds a source issuing the given claims.
+func testSource(id string, authorityType core.AuthorityType, claims ...map[string]interface{}) core.AuthoritySource {
+	list := make([]interface{}, len(claims))
+	for i, claim := range claims {
+		list[i] = claim
+	}
+	return core.AuthoritySource{
+		ID:       id,
+		Type:     authorityType,
+		Name:     id,
+		Version:  "1.0.0",
+		Metadata: map[string]interface{}{"claims": list},
+	}
+}
+
+// testClaim builds an unscoped claim definition.
+func testClaim(id, claimType, subject, action, resource string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       id,
+		"type":     claimType,
+		"subject":  subject,
+		"action":   action,
+		"resource": resource,
+		"scope":    map[string]interface{}{},
+	}
+}
+
+func compileSources(t *testing.T, sources ...core.AuthoritySource) core.AuthorityArtifact {
+	t.Helper()
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected CompilationSuccess, got %+v", result)
+	}
+	return success.Artifact
+}
+
+func TestLowerAuthorityWildcardProhibitionYieldsInOverlap(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("constitution", core.Sovereign,
+			testClaim("public_read", "permission", "*", "read", "/repos/public")),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("no_repo_read", "prohibition", "contractor", "read", "/repos/*")),
+	)
+	runtime := core.NewRuntimeInterface(artifact)
+
+	if result := runtime.IsAuthorized("contractor", "read", "/repos/public"); result["allowed"] != true {
+		t.Errorf("sovereign permission should win in the overlap, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorized("contractor", "read", "/repos/private"); result["allowed"] != false {
+		t.Errorf("prohibition should still apply outside the overlap, got %v", result["reason"])
+	}
+
+	proof := runtime.IsAuthorized("contractor", "read", "/repos/public")["proof"].(core.DecisionProof)
+	if len(proof.Overridden) != 1 || proof.Overridden[0].ClaimID != "no_repo_read" {
+		t.Fatalf("expected no_repo_read to be recorded as overridden, got %+v", proof.Overridden)
+	}
+}
+
+func TestCoveredLowerAuthorityClaimIsRemoved(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("constitution", core.Sovereign,
+			testClaim("repo_read", "permission", "*", "read", "/repos/*")),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("no_secret_read", "prohibition", "contractor", "read", "/repos/secret")),
+	)
+
+	for _, claim := range artifact.Claims {
+		if claim.ID == "no_secret_read" {
+			t.Fatal("prohibition covered by a higher-authority permission should be removed")
+		}
+	}
+	if len(artifact.Resolutions) != 1 {
+		t.Fatalf("expected one resolution record, got %+v", artifact.Resolutions)
+	}
+	record := artifact.Resolutions[0]
+	if record.ClaimID != "vendor_contract:no_secret_read" || record.By != "constitution:repo_read" || record.Edge != core.Overrides ||
+		record.Strategy != core.StrategyPrecedence {
+		t.Errorf("unexpected resolution record: %+v", record)
+	}
+	if record.WinnerKey.Compare(record.LoserKey) >= 0 {
+		t.Errorf("winner key %v should rank before loser key %v", record.WinnerKey, record.LoserKey)
+	}
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorized("contractor", "read", "/repos/secret"); result["allowed"] != true {
+		t.Errorf("expected sovereign permission to decide, got %v", result["reason"])
+	}
+}
+
+func TestHigherAuthorityProhibitionCarvesOutOfWildcardPermission(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("statute", core.Legal,
+			testClaim("no_secret_read", "prohibition", "*", "read", "/repos/secret")),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("repo_read", "permission", "contractor", "read", "/repos/*")),
+	)
+
+	found := false
+	for _, edge := range artifact.Graph.Edges {
+		if edge.EdgeType == core.Overrides && edge.FromID == "statute:no_secret_read" && edge.ToID == "vendor_contract:repo_read" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected overrides edge from no_secret_read to repo_read, got %+v", artifact.Graph.Edges)
+	}
+
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorized("contractor", "read", "/repos/secret"); result["allowed"] != false {
+		t.Errorf("expected prohibition in the overlap, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorized("contractor", "read", "/repos/docs"); result["allowed"] != true {
+		t.Errorf("expected permission outside the overlap, got %v", result["reason"])
+	}
+}
+
+// withScope sets a claim definition's scope.
+func withScope(claim map[string]interface{}, scope map[string]interface{}) map[string]interface{} {
+	claim["scope"] = scope
+	return claim
+}
+
+func hasEdge(artifact core.AuthorityArtifact, from, to string, edgeType core.EdgeType) bool {
+	for _, edge := range artifact.Graph.Edges {
+		if edge.FromID == from && edge.ToID == to && edge.EdgeType == edgeType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDisjointScopesCoexist(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("group_policy", core.Organizational,
+			withScope(testClaim("us_export", "permission", "analyst", "export", "/customers"),
+				map[string]interface{}{"jurisdictions": []string{"US"}}),
+			withScope(testClaim("eu_no_export", "prohibition", "analyst", "export", "/customers"),
+				map[string]interface{}{"jurisdictions": []string{"EU"}}),
+		),
+	)
+
+	if len(artifact.Claims) != 2 {
+		t.Fatalf("expected both claims to survive, got %d", len(artifact.Claims))
+	}
+	if hasEdge(artifact, "group_policy:eu_no_export", "group_policy:us_export", core.Overrides) ||
+		hasEdge(artifact, "group_policy:us_export", "group_policy:eu_no_export", core.Overrides) {
+		t.Error("claims with disjoint scopes should not override one another")
+	}
+
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorizedInContext("analyst", "export", "/customers", core.RequestContext{Jurisdiction: "US"}); result["allowed"] != true {
+		t.Errorf("expected US export to be permitted, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorizedInContext("analyst", "export", "/customers", core.RequestContext{Jurisdiction: "EU"}); result["allowed"] != false {
+		t.Errorf("expected EU export to be prohibited, got %v", result["reason"])
+	}
+}
+
+func TestPartialScopeOverlapResolvesOnlyTheIntersection(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("regulator", core.Regulatory,
+			withScope(testClaim("eu_retention", "permission", "ops", "retain", "/logs/*"),
+				map[string]interface{}{"jurisdictions": []string{"EU"}}),
+		),
+		testSource("vendor_contract", core.Contractual,
+			withScope(testClaim("no_retention", "prohibition", "ops", "retain", "/logs/*"),
+				map[string]interface{}{"jurisdictions": []string{"EU", "US"}}),
+		),
+	)
+
+	if !hasEdge(artifact, "regulator:eu_retention", "vendor_contract:no_retention", core.Overrides) {
+		t.Fatalf("expected eu_retention to override no_retention in EU, got %+v", artifact.Graph.Edges)
+	}
+
+	runtime := core.NewRuntimeInterface(artifact)
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if result := runtime.IsAuthorizedInContext("ops", "retain", "/logs/app", core.RequestContext{Jurisdiction: "EU", Time: at}); result["allowed"] != true {
+		t.Errorf("expected EU retention to be permitted, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorizedInContext("ops", "retain", "/logs/app", core.RequestContext{Jurisdiction: "US", Time: at}); result["allowed"] != false {
+		t.Errorf("expected US retention to be prohibited, got %v", result["reason"])
+	}
+}
+
+func TestOverlapDetectionFollowsWildcardRules(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("statute", core.Legal,
+			testClaim("no_repos", "prohibition", "dev", "*", "/repos/*")),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("secret", "permission", "dev", "read", "/repos/secret"),
+			testClaim("docs", "permission", "dev", "write", "/repos/docs/*"),
+			testClaim("rep_prefix", "permission", "dev", "delete", "/rep*"),
+			testClaim("anything", "permission", "dev", "export", "*"),
+			testClaim("short", "permission", "dev", "list", "/re"),
+			testClaim("reports", "permission", "dev", "share", "/reports/*")),
+	)
+
+	removed := []string{}
+	for _, record := range artifact.Resolutions {
+		if record.By != "statute:no_repos" || record.Edge != core.Overrides {
+			t.Errorf("unexpected resolution record: %+v", record)
+		}
+		removed = append(removed, record.ClaimID)
+	}
+	if !reflect.DeepEqual(removed, []string{"vendor_contract:docs", "vendor_contract:secret"}) {
+		t.Errorf("expected the covered claims to be removed, got %v", removed)
+	}
+	overridden := []string{}
+	for _, edge := range artifact.Graph.Edges {
+		if edge.EdgeType == core.Overrides {
+			overridden = append(overridden, edge.FromID+">"+edge.ToID)
+		}
+	}
+	sort.Strings(overridden)
+	if !reflect.DeepEqual(overridden, []string{"statute:no_repos>vendor_contract:anything", "statute:no_repos>vendor_contract:rep_prefix"}) {
+		t.Errorf("expected only the overlapping claims to be overridden, got %v", overridden)
+	}
+}
+
+func TestLaterProhibitionSlicesPermissionWindow(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("regulator", core.Regulatory,
+			withScope(testClaim("export_ban", "prohibition", "analyst", "export", "/customers"),
+				map[string]interface{}{"time_start": "2024-07-01T00:00:00Z"}),
+		),
+		testSource("vendor_contract", core.Contractual,
+			withScope(testClaim("export_allowed", "permission", "analyst", "export", "/customers"),
+				map[string]interface{}{"time_start": "2023-01-01T00:00:00Z", "time_end": "2025-01-01T00:00:00Z"}),
+		),
+	)
+
+	if len(artifact.Timeline) != 1 {
+		t.Fatalf("expected one timeline entry, got %+v", artifact.Timeline)
+	}
+	slice := artifact.Timeline[0]
+	cutoff := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if slice.ClaimID != "vendor_contract:export_allowed" || slice.TimeEnd == nil || !slice.TimeEnd.Equal(cutoff) {
+		t.Errorf("expected export_allowed to end at %v, got %+v", cutoff, slice)
+	}
+
+	runtime := core.NewRuntimeInterface(artifact)
+	before := core.RequestContext{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	after := core.RequestContext{Time: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)}
+	if result := runtime.IsAuthorizedInContext("analyst", "export", "/customers", before); result["allowed"] != true {
+		t.Errorf("expected export before the ban, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorizedInContext("analyst", "export", "/customers", after); result["allowed"] != false {
+		t.Errorf("expected the ban to apply, got %v", result["reason"])
+	}
+
+	success := core.NewAuthorityCompiler().EmitProof(artifact)
+	if !strings.Contains(success, `"origin_id": "vendor_contract:export_allowed"`) {
+		t.Errorf("proof should include the timeline:\n%s", success)
+	}
+}
+
+func TestProhibitionInsidePermissionWindowSplitsIt(t *testing.T) {
+	artifact := compileSources(t,
+		testSource("regulator", core.Regulatory,
+			withScope(testClaim("moratorium", "prohibition", "analyst", "export", "/customers"),
+				map[string]interface{}{"time_start": "2024-01-01T00:00:00Z", "time_end": "2024-07-01T00:00:00Z"}),
+		),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("export_allowed", "permission", "analyst", "export", "/customers")),
+	)
+
+	ids := map[string]bool{}
+	for _, claim := range artifact.Claims {
+		ids[claim.ID] = true
+	}
+	if ids["export_allowed"] || !ids["export_allowed#1"] || !ids["export_allowed#2"] {
+		t.Fatalf("expected export_allowed to be split in two, got %v", ids)
+	}
+
+	runtime := core.NewRuntimeInterface(artifact)
+	for _, tc := range []struct {
+		at      time.Time
+		allowed bool
+	}{
+		{time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), true},
+	} {
+		result := runtime.IsAuthorizedInContext("analyst", "export", "/customers", core.RequestContext{Time: tc.at})
+		if result["allowed"] != tc.allowed {
+			t.Errorf("at %v: expected allowed=%v, got %v", tc.at, tc.allowed, result["reason"])
+		}
+	}
+}
+
+func TestDelegationSurvivesTimeSlicing(t *testing.T) {
+	delegator := testClaim("records_admin", "delegation", "admin", "grant", "/customers")
+	delegator["conditions"] = map[string]interface{}{"delegates_to": "export_allowed"}
+	artifact := compileSources(t,
+		testSource("regulator", core.Regulatory,
+			withScope(testClaim("moratorium", "prohibition", "analyst", "export", "/customers"),
+				map[string]interface{}{"time_start": "2024-01-01T00:00:00Z", "time_end": "2024-07-01T00:00:00Z"}),
+		),
+		testSource("vendor_contract", core.Contractual,
+			delegator,
+			testClaim("export_allowed", "permission", "analyst", "export", "/customers")),
+	)
+
+	for _, slice := range []string{"vendor_contract:export_allowed#1", "vendor_contract:export_allowed#2"} {
+		if !hasEdge(artifact, "vendor_contract:records_admin", slice, core.Delegates) {
+			t.Errorf("expected the delegation to carry over to %s, got %+v", slice, artifact.Graph.Edges)
+		}
+	}
+	result := core.NewRuntimeInterface(artifact).Authorize(core.AuthorizationRequest{
+		Subject: "analyst", Action: "export", Resource: "/customers",
+		Context: &core.RequestContext{Time: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if path := result.Proof.DelegationPath; len(path) != 2 || path[0] != "vendor_contract:export_allowed#2" || path[1] != "vendor_contract:records_admin" {
+		t.Errorf("expected the delegation path through the slice, got %v (%s)", path, result.Reason)
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"are/core"
+	"are/grpcclient"
+	"are/grpcserver"
+)
+
+// grpcFixture serves runtime over an in-process bufconn listener.
+func grpcFixture(t *testing.T, runtime *core.RuntimeInterface) *grpcclient.Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	grpcserver.New(runtime).Register(srv)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return grpcclient.New(conn)
+}
+
+func TestGRPCDecisionService(t *testing.T) {
+	audit := testClaim("audit_exports", "obligation", "analyst", "export", "/reports/*")
+	audit["conditions"] = map[string]interface{}{"retain_days": 90}
+	artifact := compileSources(t, withStrategy(testSource("policy", core.Organizational,
+		testClaim("export_reports", "permission", "analyst", "export", "/reports/*"),
+		testClaim("no_secret_export", "prohibition", "analyst", "export", "/reports/secret"),
+		audit,
+	), core.StrategyDenyOverrides))
+	client := grpcFixture(t, core.NewRuntimeInterface(artifact))
+	ctx := context.Background()
+	req := core.AuthorizationRequest{Subject: "analyst", Action: "export", Resource: "/reports/q3",
+		Context: &core.RequestContext{Jurisdiction: "EU", Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}
+
+	result, err := client.Authorize(ctx, req)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if !result.Allowed || result.AuthorityID != "export_reports" || result.Generation != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if err := core.VerifyDecisionProof(artifact, result.Proof); err != nil {
+		t.Errorf("proof received over gRPC does not verify: %v", err)
+	}
+
+	results, err := client.AuthorizeBatch(ctx, []core.AuthorizationRequest{
+		{Subject: "analyst", Action: "export", Resource: "/reports/secret"},
+		{Subject: "intern", Action: "export", Resource: "/reports/q3"},
+	})
+	if err != nil {
+		t.Fatalf("AuthorizeBatch: %v", err)
+	}
+	if len(results) != 2 || results[0].AuthorityID != "no_secret_export" || results[1].Allowed {
+		t.Fatalf("unexpected batch results: %+v", results)
+	}
+
+	obligations, err := client.ObligationsFor(ctx, req)
+	if err != nil {
+		t.Fatalf("ObligationsFor: %v", err)
+	}
+	if len(obligations) != 1 || obligations[0].ID != "audit_exports" || obligations[0].Conditions["retain_days"] != float64(90) {
+		t.Fatalf("unexpected obligations: %+v", obligations)
+	}
+
+	info, err := client.AuthorityInfoFor(ctx, req)
+	if err != nil {
+		t.Fatalf("AuthorityInfoFor: %v", err)
+	}
+	if info.ArtifactID != artifact.ID || len(info.ApplicableClaims) != 2 || info.TotalClaims != 3 {
+		t.Fatalf("unexpected authority info: %+v", info)
+	}
+
+	_, err = client.Authorize(ctx, core.AuthorizationRequest{Subject: "analyst"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an incomplete request, got %v", err)
+	}
+}
+
+func TestGRPCWatchGenerationFollowsSwaps(t *testing.T) {
+	first := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("read_reports", "permission", "analyst", "read", "/reports/*")))
+	second := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("no_reports", "prohibition", "analyst", "read", "/reports/*")))
+	runtime := core.NewRuntimeInterface(first)
+	client := grpcFixture(t, runtime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := make(chan grpcclient.Generation)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WatchGeneration(ctx, func(g grpcclient.Generation) error {
+			events <- g
+			return nil
+		})
+	}()
+
+	if g := <-events; g.Generation != 1 || g.ArtifactID != first.ID {
+		t.Fatalf("expected the current generation first, got %+v", g)
+	}
+	if _, err := runtime.Swap(second); err != nil {
+		t.Fatalf("Swap: %v", err)
+	}
+	if g := <-events; g.Generation != 2 || g.ArtifactID != second.ID {
+		t.Fatalf("expected generation 2 after Swap, got %+v", g)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("WatchGeneration should end cleanly when cancelled, got %v", err)
+	}
+}
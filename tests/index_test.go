@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"are/core"
+)
+
+func indexedArtifact(claims []core.Claim) core.AuthorityArtifact {
+	nodes := make(map[string]core.Claim, len(claims))
+	for _, claim := range claims {
+		nodes[claim.ID] = claim
+	}
+	return core.AuthorityArtifact{
+		ID:      "sha256:index-fixture",
+		Sources: []core.SourceInfo{{ID: "policy", Type: core.Organizational, Version: "1.0.0"}},
+		Claims:  claims,
+		Graph:   core.AuthorityGraph{Nodes: nodes, Edges: []core.Edge{}},
+	}
+}
+
+func TestIndexedLookupFollowsWildcardRules(t *testing.T) {
+	claim := func(id, subject, resource string) core.Claim {
+		return core.Claim{ID: id, Type: core.Permission, Subject: subject, Action: "read", Resource: resource, SourceID: "policy"}
+	}
+	runtime := core.NewRuntimeInterface(indexedArtifact([]core.Claim{
+		claim("anything", "*", "*"),
+		claim("repos", "dev", "/repos/*"),
+		claim("secret", "dev", "/repos/secret"),
+		claim("rep_prefix", "dev", "/rep*"),
+		claim("literal_star", "dev", "/repos/a*b"),
+		claim("other_subject", "ops", "/repos/*"),
+		claim("dev_prefix", "dev*", "/repos/secret"),
+	}))
+
+	cases := []struct {
+		resource string
+		want     []string
+	}{
+		{"/repos/secret", []string{"anything", "repos", "secret", "rep_prefix", "dev_prefix"}},
+		{"/repos/a*b", []string{"anything", "repos", "rep_prefix", "literal_star"}},
+		{"/repos/axb", []string{"anything", "repos", "rep_prefix"}},
+		{"/repos", []string{"anything", "rep_prefix"}},
+		{"/re", []string{"anything"}},
+	}
+	for _, tc := range cases {
+		info := runtime.AuthorityInfoFor(core.AuthorizationRequest{Subject: "dev", Action: "read", Resource: tc.resource})
+		got := []string{}
+		for _, claim := range info.ApplicableClaims {
+			got = append(got, claim.ID)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.resource, tc.want, got)
+		}
+	}
+}
+
+// BenchmarkAuthorize100kClaims measures one decision against 100,000 claims,
+// built directly rather than compiled so the benchmark times the runtime alone.
+// Most claims are delegated, in chains of up to four, so that ranking and the
+// decision proof have a large graph to consult.
+func BenchmarkAuthorize100kClaims(b *testing.B) {
+	actions := []string{"read", "write", "delete", "export"}
+	claims := make([]core.Claim, 0, 100000)
+	edges := make([]core.Edge, 0, cap(claims))
+	for i := 0; len(claims) < cap(claims); i++ {
+		if i%4 != 0 {
+			edges = append(edges, core.Edge{FromID: fmt.Sprintf("claim_%06d", i-1), ToID: fmt.Sprintf("claim_%06d", i), EdgeType: core.Delegates})
+		}
+		claimType := core.Permission
+		if i%10 == 0 {
+			claimType = core.Prohibition
+		}
+		claims = append(claims, core.Claim{
+			ID:       fmt.Sprintf("claim_%06d", i),
+			Type:     claimType,
+			Subject:  fmt.Sprintf("team_%d", i%500),
+			Action:   actions[i%len(actions)],
+			Resource: fmt.Sprintf("/tenants/%d/docs/*", i),
+			SourceID: "policy",
+		})
+	}
+	artifact := indexedArtifact(claims)
+	artifact.Graph.Edges = edges
+	runtime := core.NewRuntimeInterface(artifact)
+	req := core.AuthorizationRequest{Subject: "team_42", Action: "delete", Resource: "/tenants/54542/docs/plan.md"}
+	result := runtime.Authorize(req)
+	if !result.Allowed {
+		b.Fatalf("expected the fixture to allow the request, got %s", result.Reason)
+	}
+	if len(result.Proof.DelegationPath) != 3 {
+		b.Fatalf("expected the deciding claim to be delegated twice, got %v", result.Proof.DelegationPath)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.Authorize(req)
+	}
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"are/core"
+	"are/loader"
+)
+
+const yamlSources = `id: gdpr
+type: regulatory
+name: GDPR
+version: 1.0.0
+claims:
+  - id: export_restricted
+    type: prohibition
+    subject: analyst
+    action: export
+    resource: /customers/*
+    scope:
+      jurisdictions: [EU]
+      time_start: 2018-05-25T00:00:00Z
+---
+id: company_policy
+type: organizational
+name: Company Policy
+version: 2.0.0
+metadata:
+  owner: security
+claims:
+  - id: export_allowed
+    type: permission
+    subject: analyst
+    action: export
+    resource: /customers/*
+    conditions:
+      ticket: SEC-12
+`
+
+func TestLoadYAMLMultiDocument(t *testing.T) {
+	sources, err := loader.LoadYAML("policy.yaml", strings.NewReader(yamlSources))
+	if err != nil {
+		t.Fatalf("LoadYAML failed: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(sources))
+	}
+	if sources[1].Metadata["owner"] != "security" {
+		t.Errorf("expected free-form metadata to be kept, got %v", sources[1].Metadata)
+	}
+
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected loaded sources to compile, got %#v", result)
+	}
+	if len(success.Artifact.Claims) == 0 {
+		t.Fatal("expected compiled claims")
+	}
+
+	claim := success.Artifact.Claims[0]
+	for _, c := range success.Artifact.Claims {
+		if c.ID == "export_restricted" {
+			claim = c
+		}
+	}
+	if claim.ID != "export_restricted" || claim.Scope.TimeStart == nil || len(claim.Scope.Jurisdictions) != 1 {
+		t.Fatalf("expected scope to be loaded for export_restricted, got %#v", claim)
+	}
+}
+
+func TestLoadJSONArrayAndStream(t *testing.T) {
+	array := `[
+	{"id": "a", "type": "legal", "claims": [
+		{"id": "a1", "type": "permission", "subject": "s", "action": "read", "resource": "/x"}
+	]},
+	{"id": "b", "type": "contractual"}
+]`
+	stream := `{"id": "a", "type": "legal"}
+{"id": "b", "type": "contractual"}`
+
+	for name, input := range map[string]string{"array.json": array, "stream.json": stream} {
+		sources, err := loader.LoadJSON(name, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: LoadJSON failed: %v", name, err)
+		}
+		if len(sources) != 2 || sources[0].ID != "a" || sources[1].ID != "b" {
+			t.Fatalf("%s: unexpected sources %#v", name, sources)
+		}
+	}
+}
+
+func TestLoaderRejectsUnknownFieldsWithPosition(t *testing.T) {
+	input := strings.Replace(yamlSources, "    action: export\n    resource: /customers/*\n    scope:", "    action: export\n    resource: /customers/*\n    effect: deny\n    scope:", 1)
+	_, err := loader.LoadYAML("policy.yaml", strings.NewReader(input))
+
+	var loadErr *loader.Error
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected loader.Error, got %v", err)
+	}
+	if loadErr.Line != 11 || !strings.Contains(err.Error(), `"effect"`) {
+		t.Fatalf("expected unknown field at line 11, got %v", err)
+	}
+}
+
+func TestLoaderPositionsClaimErrors(t *testing.T) {
+	input := strings.Replace(yamlSources, "time_start: 2018-05-25T00:00:00Z", "time_start: 2018-05-25", 1)
+	_, err := loader.LoadYAML("policy.yaml", strings.NewReader(input))
+	var loadErr *loader.Error
+	if !errors.As(err, &loadErr) || loadErr.Line != 13 {
+		t.Fatalf("expected scope error at line 13, got %v", err)
+	}
+
+	json := `{"id": "a", "type": "legal", "claims": [
+  {"id": "a1", "type": "permission", "subject": "s", "action": "read", "resource": "/x"}
+]}
+{"id": "b", "type": "legal", "claims": [
+  {"id": "b1", "type": "grant", "subject": "s", "action": "read", "resource": "/x"}
+]}`
+	_, err = loader.LoadJSON("sources.json", strings.NewReader(json))
+	if !errors.As(err, &loadErr) || loadErr.Line != 5 {
+		t.Fatalf("expected claim type error at line 5, got %v", err)
+	}
+	missing := strings.Replace(json[:strings.Index(json, "\n{")], `, "resource": "/x"`, "", 1)
+	_, err = loader.LoadJSON("sources.json", strings.NewReader(missing))
+	if !errors.As(err, &loadErr) || loadErr.Line != 2 {
+		t.Fatalf("expected missing resource error at line 2, got %v", err)
+	}
+}
+
+func TestLoaderRejectsInvalidSources(t *testing.T) {
+	cases := map[string]string{
+		"bad type":       "id: a\ntype: federal\n",
+		"missing id":     "type: legal\n",
+		"claims in meta": "id: a\ntype: legal\nmetadata:\n  claims: []\n",
+		"syntax":         "id: a\n  type: [legal\n",
+	}
+	for name, input := range cases {
+		if _, err := loader.LoadYAML("bad.yaml", strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected load error", name)
+		}
+	}
+}
+
+func TestLoadFilesByExtension(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "policy.yml")
+	jsonPath := filepath.Join(dir, "contract.json")
+	textPath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(yamlPath, []byte("id: a\ntype: legal\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jsonPath, []byte(`{"id": "b", "type": "contractual"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(textPath, []byte("id: c"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sources, err := loader.LoadFiles(yamlPath, jsonPath)
+	if err != nil {
+		t.Fatalf("LoadFiles failed: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(sources))
+	}
+	if _, err := loader.LoadFile(textPath); !errors.Is(err, loader.ErrUnsupportedFormat) {
+		t.Fatalf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
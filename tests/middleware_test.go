@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"are/core"
+	"are/middleware"
+)
+
+func enforcedHandler(t *testing.T) http.Handler {
+	t.Helper()
+	artifact := compileSources(t, withStrategy(testSource("policy", core.Organizational,
+		testClaim("read_repos", "permission", "engineer", "read", "/repos/*"),
+		testClaim("no_secret", "prohibition", "engineer", "read", "/repos/secret"),
+		testClaim("log_reads", "obligation", "engineer", "read", "/repos/*"),
+	), core.StrategyDenyOverrides))
+	enforcer := middleware.New(core.NewRuntimeInterface(artifact), middleware.Header("X-User"),
+		middleware.WithAction(middleware.MethodActions(map[string]string{http.MethodGet: "read"})))
+	return enforcer.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, ok := middleware.Decision(r.Context())
+		if !ok {
+			t.Error("handler called without a decision in its context")
+		}
+		w.Header().Set("X-Authority", decision.AuthorityID)
+		for _, obligation := range middleware.Obligations(r.Context()) {
+			w.Header().Add("X-Obligation", obligation.ID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func serveRequest(handler http.Handler, method, path, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewarePassesPermittedRequestsWithObligations(t *testing.T) {
+	rec := serveRequest(enforcedHandler(t), http.MethodGet, "/repos/main", "engineer")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected the handler to run, got %d %s", rec.Code, rec.Body)
+	}
+	if rec.Header().Get("X-Authority") != "read_repos" || rec.Header().Get("X-Obligation") != "log_reads" {
+		t.Errorf("expected the decision and obligations in the context, got %v", rec.Header())
+	}
+}
+
+func TestMiddlewareFailsClosed(t *testing.T) {
+	handler := enforcedHandler(t)
+	cases := []struct {
+		name, method, path, user string
+		authorityID              string
+	}{
+		{"prohibited", http.MethodGet, "/repos/secret", "engineer", "no_secret"},
+		{"no authority", http.MethodGet, "/repos/main", "intern", ""},
+		{"unmapped method", http.MethodDelete, "/repos/main", "engineer", ""},
+		{"no subject", http.MethodGet, "/repos/main", "", ""},
+	}
+	for _, tc := range cases {
+		rec := serveRequest(handler, tc.method, tc.path, tc.user)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", tc.name, rec.Code)
+			continue
+		}
+		var body middleware.DenyResponse
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: decoding deny body: %v", tc.name, err)
+		}
+		if body.Error != "forbidden" || body.Reason == "" {
+			t.Errorf("%s: unexpected deny body %+v", tc.name, body)
+		}
+		if tc.authorityID != "" && body.AuthorityID != tc.authorityID {
+			t.Errorf("%s: expected %s to deny, got %+v", tc.name, tc.authorityID, body)
+		}
+	}
+}
+
+func TestMiddlewareContextExtractorEnforcesScope(t *testing.T) {
+	artifact := compileSources(t, testSource("policy", core.Organizational,
+		withScope(testClaim("eu_read", "permission", "engineer", "read", "/repos/*"),
+			map[string]interface{}{"jurisdictions": []string{"EU"}}),
+	))
+	enforcer := middleware.New(core.NewRuntimeInterface(artifact), middleware.Header("X-User"),
+		middleware.WithAction(middleware.MethodActions(map[string]string{http.MethodGet: "read"})),
+		middleware.WithContext(func(r *http.Request) (*core.RequestContext, error) {
+			return &core.RequestContext{Jurisdiction: r.Header.Get("X-Region")}, nil
+		}))
+	handler := enforcer.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for region, want := range map[string]int{"EU": http.StatusNoContent, "US": http.StatusForbidden, "": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodGet, "/repos/main", nil)
+		req.Header.Set("X-User", "engineer")
+		req.Header.Set("X-Region", region)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("region %q: expected %d, got %d", region, want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"are/core"
+	"are/policy"
+)
+
+const policyText = `# Engineering access policy
+SOURCE company_policy ORGANIZATIONAL VERSION 2.1.0 NAME "Engineering Access Policy"
+
+PERMIT engineer READ /repos/* IN US,EU FOR read,clone AS eng_read
+PROHIBIT intern WRITE /repos/* IN US,EU DURING 2025-01-01..2026-01-01 UNDER source company_policy
+OBLIGE engineer AUDIT /repos/* AS audit_trail WHERE retention = "90 days", ticket = SEC-1
+permit admin grant /data AS data_admin delegates to data_reader
+DELEGATE service READ /data IN US FOR read AS data_reader
+PERMIT ops RETAIN /logs/* AS retain_logs
+
+SOURCE statute LEGAL VERSION 1.0.0
+PROHIBIT ops RETAIN /logs/* DURING 2024-06-01T12:00:00Z.. AS retention_limit
+    REVOKES company_policy:retain_logs
+`
+
+func TestParsePolicyProducesNormalizedClaims(t *testing.T) {
+	file, err := policy.Parse("access.are", []byte(policyText))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(file.Sources) != 2 || len(file.Claims) != 7 {
+		t.Fatalf("expected 2 sources and 7 claims, got %d and %d", len(file.Sources), len(file.Claims))
+	}
+
+	claims, err := file.CoreClaims()
+	if err != nil {
+		t.Fatalf("CoreClaims failed: %v", err)
+	}
+	intern := claims[1]
+	if intern.ID != "company_policy_2" || intern.Type != core.Prohibition || intern.Action != "write" {
+		t.Fatalf("unexpected intern claim %#v", intern)
+	}
+	if intern.Scope.TimeStart == nil || intern.Scope.TimeStart.Format("2006-01-02") != "2025-01-01" {
+		t.Fatalf("expected DURING start to be parsed, got %v", intern.Scope.TimeStart)
+	}
+	if claims[2].Conditions["retention"] != "90 days" {
+		t.Errorf("expected WHERE condition, got %v", claims[2].Conditions)
+	}
+	if claims[6].SourceID != "statute" || claims[6].Conditions["revokes"] != "company_policy:retain_logs" {
+		t.Errorf("expected revocation under statute, got %#v", claims[6])
+	}
+
+	// Text and map-literal sources must compile to the same claims
+	sources, err := file.AuthoritySources()
+	if err != nil {
+		t.Fatalf("AuthoritySources failed: %v", err)
+	}
+	artifact, err := core.NewAuthorityCompiler().NormalizeSources(context.Background(), sources)
+	if err != nil {
+		t.Fatalf("NormalizeSources failed: %v", err)
+	}
+	if !reflect.DeepEqual(artifact.Claims, claims) {
+		t.Fatalf("normalized claims differ from parsed claims:\n%#v\n%#v", artifact.Claims, claims)
+	}
+
+	result := core.NewAuthorityCompiler().ProcessSources(sources)
+	if _, ok := result.(core.CompilationSuccess); !ok {
+		t.Fatalf("expected policy to compile, got %#v", result)
+	}
+}
+
+func TestFormatRoundTrips(t *testing.T) {
+	file, err := policy.Parse("access.are", []byte(policyText))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	formatted := policy.Format(file)
+	if !strings.Contains(formatted, "PROHIBIT intern WRITE /repos/* IN US,EU DURING 2025-01-01..2026-01-01 AS company_policy_2\n") {
+		t.Errorf("unexpected canonical form:\n%s", formatted)
+	}
+
+	reparsed, err := policy.Parse("formatted.are", []byte(formatted))
+	if err != nil {
+		t.Fatalf("formatted output does not parse: %v\n%s", err, formatted)
+	}
+	if again := policy.Format(reparsed); again != formatted {
+		t.Fatalf("Format is not stable:\n%s\n---\n%s", formatted, again)
+	}
+
+	original, _ := file.CoreClaims()
+	roundTripped, _ := reparsed.CoreClaims()
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Fatal("round-tripped claims differ from the original")
+	}
+}
+
+func TestFormatKeepsDeclarationOrder(t *testing.T) {
+	file, err := policy.Parse("interleaved.are", []byte(`SOURCE contract CONTRACTUAL
+SOURCE company ORGANIZATIONAL
+PERMIT analyst READ /reports/* UNDER contract AS contract_read
+PROHIBIT analyst READ /reports/* AS company_no_read
+OBLIGE analyst READ /reports/* UNDER contract AS contract_log
+SOURCE unused LEGAL
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	formatted := policy.Format(file)
+	want := `SOURCE contract CONTRACTUAL
+
+PERMIT analyst READ /reports/* AS contract_read
+
+SOURCE company ORGANIZATIONAL
+
+PROHIBIT analyst READ /reports/* AS company_no_read
+OBLIGE analyst READ /reports/* UNDER SOURCE contract AS contract_log
+
+SOURCE unused LEGAL
+
+`
+	if formatted != want {
+		t.Fatalf("unexpected canonical form:\n%s", formatted)
+	}
+
+	reparsed, err := policy.Parse("formatted.are", []byte(formatted))
+	if err != nil {
+		t.Fatalf("formatted output does not parse: %v\n%s", err, formatted)
+	}
+	original, _ := file.CoreClaims()
+	roundTripped, _ := reparsed.CoreClaims()
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Fatalf("round-tripped claims differ from the original:\n%+v\n%+v", original, roundTripped)
+	}
+}
+
+func TestParseReportsPositions(t *testing.T) {
+	cases := []struct {
+		src  string
+		line int
+		col  int
+		msg  string
+	}{
+		{"PERMIT a b /c", 1, 1, "no source"},
+		{"SOURCE s LEGAL\nPERMIT a b /c IN US FOR", 2, 24, "expected operation"},
+		{"SOURCE s LEGAL\nGRANT a b /c", 2, 1, "unknown statement"},
+		{"SOURCE s FEDERAL", 1, 10, "invalid authority type"},
+		{"SOURCE s LEGAL\nPERMIT a b /c DURING 2025-13-01..", 2, 22, "invalid window start"},
+		{"SOURCE s LEGAL\nPERMIT a b /c AS x\nPERMIT a b /d AS x", 3, 1, "already used"},
+		{"SOURCE s LEGAL\nPERMIT a b /c UNDER other", 2, 1, "undeclared source"},
+		{"SOURCE s LEGAL\nPERMIT a b /c WHERE revokes = x", 2, 21, "REVOKES clause"},
+		{"SOURCE s LEGAL NAME \"open", 1, 21, "unterminated string"},
+	}
+	for _, tc := range cases {
+		_, err := policy.Parse("bad.are", []byte(tc.src))
+		var perr *policy.Error
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expected policy.Error, got %v", tc.src, err)
+			continue
+		}
+		if perr.Pos.Line != tc.line || perr.Pos.Column != tc.col || !strings.Contains(err.Error(), tc.msg) {
+			t.Errorf("%q: expected %d:%d %q, got %v", tc.src, tc.line, tc.col, tc.msg, err)
+		}
+	}
+}
+
+func TestTokenizeContinuationLines(t *testing.T) {
+	tokens, err := policy.Tokenize("t.are", []byte("PERMIT a b /c\n    AS x # trailing comment\n\nPERMIT d e /f\n"))
+	if err != nil {
+		t.Fatalf("Tokenize failed: %v", err)
+	}
+	newlines := 0
+	for _, tok := range tokens {
+		if tok.Kind == policy.TokenNewline {
+			newlines++
+		}
+	}
+	if newlines != 2 {
+		t.Fatalf("expected the indented line to continue the first statement, got %d statements", newlines)
+	}
+}
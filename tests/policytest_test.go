@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"are/cli"
+	"are/core"
+	"are/policytest"
+)
+
+const accessSuite = `name: engineering access
+sources: [policy.are]
+cases:
+  - name: interns cannot write in the EU
+    request:
+      subject: intern
+      action: write
+      resource: /repos/x
+      context: {jurisdiction: EU}
+    expect:
+      decision: denied
+      authority_id: no_intern_write
+      obligations: [log_writes]
+  - name: interns can write in Japan
+    request:
+      subject: intern
+      action: write
+      resource: /repos/x
+      context: {jurisdiction: JP, time: 2025-06-01T00:00:00Z}
+    expect:
+      decision: allowed
+      authority_id: intern_write
+  - name: visitors have no authority
+    request: {subject: visitor, action: read, resource: /repos/x}
+    expect:
+      decision: denied
+      authority_id: ""
+      obligations: []
+`
+
+func TestPolicyTestSuitePasses(t *testing.T) {
+	suite, err := policytest.Parse("suite.yaml", strings.NewReader(accessSuite))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	runtime := core.NewRuntimeInterface(compileSources(t,
+		testSource("law", core.Legal,
+			withScope(testClaim("no_intern_write", "prohibition", "intern", "write", "/repos/*"),
+				map[string]interface{}{"jurisdictions": []string{"US", "EU"}})),
+		testSource("company", core.Organizational,
+			testClaim("intern_write", "permission", "intern", "write", "/repos/*"),
+			testClaim("log_writes", "obligation", "intern", "write", "/repos/*")),
+	))
+	report := suite.Run(runtime)
+	if report.Failed() != 0 {
+		var out bytes.Buffer
+		report.Write(&out, false)
+		t.Fatalf("expected every case to pass:\n%s", out.String())
+	}
+}
+
+func TestPolicyTestReportsMismatches(t *testing.T) {
+	suite, err := policytest.Parse("suite.yaml", strings.NewReader(`cases:
+  - name: engineers may read
+    request: {subject: engineer, action: read, resource: /repos/secret}
+    expect: {decision: allowed, authority_id: read_repos, obligations: [audit]}
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	runtime := core.NewRuntimeInterface(compileSources(t, withStrategy(testSource("policy", core.Organizational,
+		testClaim("read_repos", "permission", "engineer", "read", "/repos/*"),
+		testClaim("no_secret", "prohibition", "engineer", "read", "/repos/secret"),
+		testClaim("log_reads", "obligation", "engineer", "read", "/repos/*"),
+	), core.StrategyDenyOverrides)))
+	report := suite.Run(runtime)
+	if report.Failed() != 1 {
+		t.Fatalf("expected the case to fail, got %+v", report)
+	}
+	fields := []string{}
+	for _, m := range report.Results[0].Mismatches {
+		fields = append(fields, m.Field)
+	}
+	if strings.Join(fields, ",") != "decision,authority_id,obligations" {
+		t.Errorf("unexpected mismatches %+v", report.Results[0].Mismatches)
+	}
+
+	var out bytes.Buffer
+	report.Write(&out, false)
+	for _, want := range []string{
+		"--- FAIL: suite / engineers may read",
+		"- want allowed",
+		"+ got  denied",
+		"+ got  no_secret",
+		"(missing audit; unexpected log_reads)",
+		"0/1 cases passed",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in the report:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPolicyTestRejectsMalformedSuites(t *testing.T) {
+	for name, text := range map[string]string{
+		"no cases":      "name: empty\n",
+		"unknown field": "cases:\n  - name: x\n    request: {subject: a, action: b, resource: c}\n    expect: {decision: allowed, allow: true}\n",
+		"bad decision":  "cases:\n  - name: x\n    request: {subject: a, action: b, resource: c}\n    expect: {decision: maybe}\n",
+		"no request":    "cases:\n  - name: x\n    expect: {decision: denied}\n",
+	} {
+		if _, err := policytest.Parse("suite.yaml", strings.NewReader(text)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTestCommandRunsSuites(t *testing.T) {
+	src := writePolicy(t, "policy.are", explainPolicy)
+	dir := filepath.Dir(src)
+	suitePath := filepath.Join(dir, "access.yaml")
+	if err := os.WriteFile(suitePath, []byte(accessSuite), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr := runCLI("test", suitePath)
+	if code != cli.ExitOK || !strings.Contains(stdout, "ok\tengineering access\t3/3 cases passed") {
+		t.Fatalf("expected the suite to pass, got exit %d:\n%s%s", code, stdout, stderr)
+	}
+
+	failing := strings.Replace(accessSuite, "decision: allowed", "decision: denied", 1)
+	if err := os.WriteFile(suitePath, []byte(failing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, _ = runCLI("test", suitePath)
+	if code != cli.ExitFailure || !strings.Contains(stdout, "--- FAIL: engineering access / interns can write in Japan") {
+		t.Errorf("expected the suite to fail, got exit %d:\n%s", code, stdout)
+	}
+}
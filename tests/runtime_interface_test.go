@@ -0,0 +1,477 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"are/core"
+)
+
+func scopedRuntime(t *testing.T) *core.RuntimeInterface {
+	t.Helper()
+	compiler := core.NewAuthorityCompiler()
+	source := core.AuthoritySource{
+		ID:      "test_source",
+		Type:    core.Regulatory,
+		Name:    "Scoped Policy",
+		Version: "1.0",
+		Metadata: map[string]interface{}{
+			"claims": []interface{}{
+				map[string]interface{}{
+					"id":       "eu_read_2023",
+					"type":     "permission",
+					"subject":  "analyst",
+					"action":   "read",
+					"resource": "/data/*",
+					"scope": map[string]interface{}{
+						"jurisdictions": []string{"EU"},
+						"time_start":    "2023-01-01T00:00:00Z",
+						"time_end":      "2024-01-01T00:00:00Z",
+						"operations":    []string{"query"},
+					},
+				},
+				map[string]interface{}{
+					"id":       "us_export_ban",
+					"type":     "prohibition",
+					"subject":  "analyst",
+					"action":   "export",
+					"resource": "/data/*",
+					"scope": map[string]interface{}{
+						"jurisdictions": []string{"US"},
+					},
+				},
+				map[string]interface{}{
+					"id":       "export_permitted",
+					"type":     "permission",
+					"subject":  "analyst",
+					"action":   "export",
+					"resource": "/data/reports/*",
+					"scope":    map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	success, ok := compiler.Process(source).(core.CompilationSuccess)
+	if !ok {
+		t.Fatal("expected CompilationSuccess")
+	}
+	return core.NewRuntimeInterface(success.Artifact)
+}
+
+func TestIsAuthorizedInContextEnforcesScope(t *testing.T) {
+	runtime := scopedRuntime(t)
+	inWindow := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		rc      core.RequestContext
+		allowed bool
+	}{
+		{"in scope", core.RequestContext{Jurisdiction: "EU", Operation: "query", Time: inWindow}, true},
+		{"wrong jurisdiction", core.RequestContext{Jurisdiction: "US", Operation: "query", Time: inWindow}, false},
+		{"wrong operation", core.RequestContext{Jurisdiction: "EU", Operation: "delete", Time: inWindow}, false},
+		{"before window", core.RequestContext{Jurisdiction: "EU", Operation: "query", Time: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)}, false},
+		{"at window end", core.RequestContext{Jurisdiction: "EU", Operation: "query", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, false},
+		{"unstated jurisdiction", core.RequestContext{Operation: "query", Time: inWindow}, false},
+	}
+
+	for _, tc := range cases {
+		result := runtime.IsAuthorizedInContext("analyst", "read", "/data/report.csv", tc.rc)
+		if result["allowed"].(bool) != tc.allowed {
+			t.Errorf("%s: expected allowed=%v, got %v (%v)", tc.name, tc.allowed, result["allowed"], result["reason"])
+		}
+	}
+
+	// Legacy query ignores scope entirely
+	if !runtime.IsAuthorized("analyst", "read", "/data/report.csv")["allowed"].(bool) {
+		t.Error("IsAuthorized should not consult scope")
+	}
+}
+
+func TestScopedProhibitionAppliesWhenJurisdictionUnstated(t *testing.T) {
+	runtime := scopedRuntime(t)
+
+	result := runtime.IsAuthorizedInContext("analyst", "export", "/data/reports/q1.csv", core.RequestContext{Jurisdiction: "EU"})
+	if !result["allowed"].(bool) {
+		t.Fatalf("EU export should be permitted, got %v", result["reason"])
+	}
+
+	result = runtime.IsAuthorizedInContext("analyst", "export", "/data/reports/q1.csv", core.RequestContext{Jurisdiction: "US"})
+	if result["allowed"].(bool) || result["authority_id"] != "us_export_ban" {
+		t.Fatalf("US export should be prohibited by us_export_ban, got %v", result)
+	}
+
+	result = runtime.IsAuthorizedInContext("analyst", "export", "/data/reports/q1.csv", core.RequestContext{})
+	if result["allowed"].(bool) {
+		t.Fatal("scoped prohibition must apply when the request does not state a jurisdiction")
+	}
+}
+
+func TestGetAuthorityInfoInContextFiltersOutOfScopeClaims(t *testing.T) {
+	runtime := scopedRuntime(t)
+
+	info := runtime.GetAuthorityInfoInContext("analyst", "export", "/data/reports/q1.csv", core.RequestContext{Jurisdiction: "EU"})
+	claims := info["applicable_claims"].([]map[string]interface{})
+	if len(claims) != 1 || claims[0]["id"] != "export_permitted" {
+		t.Fatalf("expected only export_permitted to apply in EU, got %v", claims)
+	}
+}
+
+func TestAuthorizeBatchMatchesAuthorize(t *testing.T) {
+	runtime := scopedRuntime(t)
+	inWindow := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	reqs := []core.AuthorizationRequest{
+		{Subject: "analyst", Action: "read", Resource: "/data/report.csv",
+			Context: &core.RequestContext{Jurisdiction: "EU", Operation: "query", Time: inWindow}},
+		{Subject: "analyst", Action: "export", Resource: "/data/reports/q1.csv",
+			Context: &core.RequestContext{Jurisdiction: "US", Time: inWindow}},
+		{Subject: "intruder", Action: "read", Resource: "/data/report.csv"},
+	}
+
+	results := runtime.AuthorizeBatch(reqs)
+	if len(results) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(results))
+	}
+	want := []struct {
+		allowed     bool
+		authorityID string
+	}{
+		{true, "eu_read_2023"},
+		{false, "us_export_ban"},
+		{false, runtime.GetArtifact().ID},
+	}
+	for i, result := range results {
+		if result.Allowed != want[i].allowed || result.AuthorityID != want[i].authorityID {
+			t.Errorf("request %d: expected %+v, got %+v", i, want[i], result)
+		}
+		if single := runtime.Authorize(reqs[i]); !reflect.DeepEqual(single, result) {
+			t.Errorf("request %d: batch result %+v differs from Authorize %+v", i, result, single)
+		}
+		if err := core.VerifyDecisionProof(runtime.GetArtifact(), result.Proof); err != nil {
+			t.Errorf("request %d: proof does not verify: %v", i, err)
+		}
+	}
+	if results[0].Scope.Jurisdictions[0] != "EU" {
+		t.Errorf("expected the deciding claim's scope, got %+v", results[0].Scope)
+	}
+}
+
+func TestTypedQueriesResolveUnsetTime(t *testing.T) {
+	runtime := scopedRuntime(t)
+	req := core.AuthorizationRequest{Subject: "analyst", Action: "export", Resource: "/data/reports/q1.csv",
+		Context: &core.RequestContext{Jurisdiction: "EU"}}
+
+	info := runtime.AuthorityInfoFor(req)
+	if len(info.ApplicableClaims) != 1 || info.ApplicableClaims[0].ID != "export_permitted" {
+		t.Fatalf("expected only export_permitted to apply in EU, got %+v", info.ApplicableClaims)
+	}
+	if info.TotalClaims != 3 {
+		t.Errorf("expected 3 claims in total, got %d", info.TotalClaims)
+	}
+	if obligations := runtime.ObligationsFor(req); len(obligations) != 0 {
+		t.Errorf("expected no obligations, got %+v", obligations)
+	}
+	if result := runtime.Authorize(req); result.Proof.Request.Context.Time.IsZero() {
+		t.Error("expected the proof to record the evaluation time")
+	}
+}
+
+func TestDecisionProofTracesAuthority(t *testing.T) {
+	sources := []core.AuthoritySource{
+		{
+			ID:      "statute",
+			Type:    core.Legal,
+			Name:    "Statute",
+			Version: "2.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "admin_grant",
+						"type":     "permission",
+						"subject":  "admin",
+						"action":   "read",
+						"resource": "/records/*",
+						"scope":    map[string]interface{}{},
+						"conditions": map[string]interface{}{
+							"delegates_to": "service_read",
+						},
+					},
+					map[string]interface{}{
+						"id":       "service_read",
+						"type":     "permission",
+						"subject":  "service",
+						"action":   "read",
+						"resource": "/records/*",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			ID:      "constitution",
+			Type:    core.Sovereign,
+			Name:    "Constitution",
+			Version: "1.0.0",
+			Metadata: map[string]interface{}{
+				"claims": []interface{}{
+					map[string]interface{}{
+						"id":       "service_sealed",
+						"type":     "prohibition",
+						"subject":  "service",
+						"action":   "*",
+						"resource": "/records/sealed/*",
+						"scope":    map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+	success, ok := core.NewAuthorityCompiler().ProcessSources(sources).(core.CompilationSuccess)
+	if !ok {
+		t.Fatal("expected CompilationSuccess")
+	}
+	artifact := success.Artifact
+	runtime := core.NewRuntimeInterface(artifact)
+
+	proof := runtime.IsAuthorized("service", "read", "/records/open/1")["proof"].(core.DecisionProof)
+	if proof.Outcome != core.OutcomePermitted || proof.Claim == nil || proof.Claim.ID != "service_read" {
+		t.Fatalf("expected service_read to permit, got %#v", proof)
+	}
+	if proof.Source == nil || proof.Source.ID != "statute" || proof.Source.PrecedenceRank != 1 {
+		t.Errorf("expected statute source with legal rank, got %#v", proof.Source)
+	}
+	if len(proof.DelegationPath) != 2 || proof.DelegationPath[1] != "statute:admin_grant" {
+		t.Errorf("expected delegation path up to admin_grant, got %v", proof.DelegationPath)
+	}
+	if len(proof.Matches) != 3 || proof.Matches[2].Pattern != "/records/*" {
+		t.Errorf("expected matched fields to be recorded, got %v", proof.Matches)
+	}
+	if err := core.VerifyDecisionProof(artifact, proof); err != nil {
+		t.Fatalf("proof should verify: %v", err)
+	}
+
+	denied := runtime.IsAuthorizedInContext("service", "read", "/records/sealed/1", core.RequestContext{Jurisdiction: "US"})
+	proof = denied["proof"].(core.DecisionProof)
+	if proof.Outcome != core.OutcomeProhibited || len(proof.Overridden) != 1 || proof.Overridden[0].ClaimID != "service_read" {
+		t.Fatalf("expected service_sealed to override service_read, got %#v", proof)
+	}
+	if proof.Request.Context == nil || proof.Request.Context.Time.IsZero() {
+		t.Fatal("proof should record the evaluation time")
+	}
+	if err := core.VerifyDecisionProof(artifact, proof); err != nil {
+		t.Fatalf("context proof should verify: %v", err)
+	}
+
+	tampered := proof
+	tampered.Allowed = true
+	if err := core.VerifyDecisionProof(artifact, tampered); !errors.Is(err, core.ErrProofMismatch) {
+		t.Fatalf("tampered proof must not verify, got %v", err)
+	}
+
+	failClosed := runtime.IsAuthorized("nobody", "read", "/records/open/1")["proof"].(core.DecisionProof)
+	if failClosed.Outcome != core.OutcomeNoAuthority || failClosed.Claim != nil {
+		t.Fatalf("expected fail-closed proof, got %#v", failClosed)
+	}
+}
+
+func TestSwapReplacesArtifactAndRollsBack(t *testing.T) {
+	allow := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("read_reports", "permission", "analyst", "read", "/reports/*")))
+	deny := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("no_reports", "prohibition", "analyst", "read", "/reports/*")))
+	req := core.AuthorizationRequest{Subject: "analyst", Action: "read", Resource: "/reports/q3"}
+
+	runtime := core.NewRuntimeInterface(allow)
+	runtime.SetKeepPrevious(true)
+	if result := runtime.Authorize(req); !result.Allowed || result.Generation != 1 {
+		t.Fatalf("expected generation 1 to allow, got %+v", result)
+	}
+
+	generation, err := runtime.Swap(deny)
+	if err != nil || generation != 2 {
+		t.Fatalf("Swap: generation %d, err %v", generation, err)
+	}
+	if result := runtime.Authorize(req); result.Allowed || result.Generation != 2 {
+		t.Fatalf("expected generation 2 to deny, got %+v", result)
+	}
+
+	broken := deny
+	broken.Graph.Nodes = nil
+	if _, err := runtime.Swap(broken); err == nil {
+		t.Fatal("expected an invalid artifact to be rejected")
+	}
+	if runtime.Generation() != 2 || runtime.GetArtifact().ID != deny.ID {
+		t.Fatal("a rejected swap must leave the enforced artifact in place")
+	}
+
+	generation, err = runtime.Rollback()
+	if err != nil || generation != 3 {
+		t.Fatalf("Rollback: generation %d, err %v", generation, err)
+	}
+	if result := runtime.Authorize(req); !result.Allowed || result.Generation != 3 {
+		t.Fatalf("expected the rolled-back artifact to allow, got %+v", result)
+	}
+	if _, err := runtime.Rollback(); !errors.Is(err, core.ErrNoPreviousArtifact) {
+		t.Errorf("expected ErrNoPreviousArtifact, got %v", err)
+	}
+}
+
+func TestSnapshotDescribesOneArtifact(t *testing.T) {
+	first := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("read_reports", "permission", "analyst", "read", "/reports/*")))
+	second := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("no_reports", "prohibition", "analyst", "read", "/reports/*")))
+	runtime := core.NewRuntimeInterface(first)
+
+	snapshot := runtime.Snapshot()
+	if snapshot.Generation != 1 || snapshot.ArtifactID != first.ID {
+		t.Fatalf("expected generation 1 of %s, got %+v", first.ID, snapshot)
+	}
+	select {
+	case <-snapshot.Changed:
+		t.Fatal("Changed closed before the artifact was replaced")
+	default:
+	}
+	if _, err := runtime.Swap(second); err != nil {
+		t.Fatalf("Swap: %v", err)
+	}
+	select {
+	case <-snapshot.Changed:
+	default:
+		t.Fatal("expected Changed to be closed by Swap")
+	}
+	if next := runtime.Snapshot(); next.Generation != 2 || next.ArtifactID != second.ID {
+		t.Errorf("expected generation 2 of %s, got %+v", second.ID, next)
+	}
+}
+
+func TestSwapIsSafeDuringConcurrentQueries(t *testing.T) {
+	artifacts := []core.AuthorityArtifact{
+		compileSources(t, testSource("policy", core.Organizational,
+			testClaim("read_reports", "permission", "analyst", "read", "/reports/*"))),
+		compileSources(t, testSource("policy", core.Organizational,
+			testClaim("no_reports", "prohibition", "analyst", "read", "/reports/*"))),
+	}
+	runtime := core.NewRuntimeInterface(artifacts[0])
+	req := core.AuthorizationRequest{Subject: "analyst", Action: "read", Resource: "/reports/q3"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				result := runtime.Authorize(req)
+				// Odd generations enforce the permission, even ones the prohibition.
+				if result.Allowed != (result.Generation%2 == 1) {
+					t.Errorf("generation %d decided allowed=%v", result.Generation, result.Allowed)
+					return
+				}
+			}
+		}()
+	}
+	for i := 1; i <= 50; i++ {
+		if _, err := runtime.Swap(artifacts[i%2]); err != nil {
+			t.Fatalf("Swap: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestAuthorizeWithObligationsUsesOneArtifact(t *testing.T) {
+	artifacts := []core.AuthorityArtifact{
+		compileSources(t, testSource("policy", core.Organizational,
+			testClaim("read_reports", "permission", "analyst", "read", "/reports/*"),
+			testClaim("log_reads", "obligation", "analyst", "read", "/reports/*"))),
+		compileSources(t, testSource("policy", core.Organizational,
+			testClaim("read_reports_v2", "permission", "analyst", "read", "/reports/*"),
+			testClaim("notify_owner", "obligation", "analyst", "read", "/reports/*"))),
+	}
+	want := map[string]string{"read_reports": "log_reads", "read_reports_v2": "notify_owner"}
+	runtime := core.NewRuntimeInterface(artifacts[0])
+	req := core.AuthorizationRequest{Subject: "analyst", Action: "read", Resource: "/reports/q3"}
+
+	result, obligations := runtime.AuthorizeWithObligations(req)
+	if !reflect.DeepEqual(result, runtime.Authorize(req)) || !reflect.DeepEqual(obligations, runtime.ObligationsFor(req)) {
+		t.Fatalf("expected the same answer as Authorize and ObligationsFor, got %+v and %+v", result, obligations)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				result, obligations := runtime.AuthorizeWithObligations(req)
+				if len(obligations) != 1 || obligations[0].ID != want[result.AuthorityID] {
+					t.Errorf("decision by %s came with obligations %+v", result.AuthorityID, obligations)
+					return
+				}
+			}
+		}()
+	}
+	for i := 1; i <= 50; i++ {
+		if _, err := runtime.Swap(artifacts[i%2]); err != nil {
+			t.Fatalf("Swap: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestNewValidatedRuntimeInterfaceRefusesInvalidArtifacts(t *testing.T) {
+	artifact := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("read_reports", "permission", "analyst", "read", "/reports/*"),
+		testClaim("write_reports", "permission", "analyst", "write", "/reports/*")))
+
+	if _, err := core.NewValidatedRuntimeInterface(artifact); err != nil {
+		t.Fatalf("expected a compiled artifact to be accepted, got %v", err)
+	}
+
+	nilGraph := artifact
+	nilGraph.Graph.Nodes = nil
+	duplicate := artifact
+	duplicate.Claims = append([]core.Claim{}, artifact.Claims...)
+	duplicate.Claims[1].ID = duplicate.Claims[0].ID
+	cyclic := artifact
+	cyclic.Graph.Edges = []core.Edge{
+		{FromID: "read_reports", ToID: "write_reports", EdgeType: core.Delegates},
+		{FromID: "write_reports", ToID: "read_reports", EdgeType: core.Delegates},
+	}
+	for name, invalid := range map[string]core.AuthorityArtifact{"nil graph": nilGraph, "duplicate IDs": duplicate, "cycle": cyclic} {
+		if _, err := core.NewValidatedRuntimeInterface(invalid); err == nil {
+			t.Errorf("%s: expected the artifact to be refused", name)
+		}
+	}
+}
+
+func TestNewValidatedRuntimeInterfaceChecksFreshness(t *testing.T) {
+	artifact := compileSources(t, testSource("policy", core.Organizational,
+		testClaim("read_reports", "permission", "analyst", "read", "/reports/*")))
+	later := func() time.Time { return artifact.GeneratedAt.Add(2 * time.Hour) }
+
+	if _, err := core.NewValidatedRuntimeInterface(artifact, core.WithMaxAge(3*time.Hour), core.WithClock(later)); err != nil {
+		t.Fatalf("expected a fresh artifact to be accepted, got %v", err)
+	}
+	_, err := core.NewValidatedRuntimeInterface(artifact, core.WithMaxAge(time.Hour), core.WithClock(later))
+	if !errors.Is(err, core.ErrStaleArtifact) {
+		t.Errorf("expected ErrStaleArtifact, got %v", err)
+	}
+	earlier := func() time.Time { return artifact.GeneratedAt.Add(-time.Hour) }
+	if _, err := core.NewValidatedRuntimeInterface(artifact, core.WithMaxAge(time.Hour), core.WithClock(earlier)); !errors.Is(err, core.ErrStaleArtifact) {
+		t.Errorf("expected an artifact from the future to be refused, got %v", err)
+	}
+
+	runtime, err := core.NewValidatedRuntimeInterface(artifact, core.WithMaxAge(3*time.Hour), core.WithClock(later))
+	if err != nil {
+		t.Fatalf("NewValidatedRuntimeInterface: %v", err)
+	}
+	stale := artifact
+	stale.GeneratedAt = artifact.GeneratedAt.Add(-2 * time.Hour)
+	if _, err := runtime.Swap(stale); !errors.Is(err, core.ErrStaleArtifact) {
+		t.Errorf("expected Swap to refuse a stale artifact, got %v", err)
+	}
+}
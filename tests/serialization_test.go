@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"are/core"
+)
+
+func TestArtifactFileRoundTripPreservesSignature(t *testing.T) {
+	signer, artifact, sig := signedFixture(t)
+	path := filepath.Join(t.TempDir(), "artifact.json")
+	if err := core.WriteArtifactFile(path, artifact, &sig); err != nil {
+		t.Fatalf("WriteArtifactFile: %v", err)
+	}
+
+	loaded, loadedSig, err := core.ReadArtifactFile(path)
+	if err != nil {
+		t.Fatalf("ReadArtifactFile: %v", err)
+	}
+	if loadedSig == nil || !reflect.DeepEqual(*loadedSig, sig) {
+		t.Fatalf("signature not preserved: %+v", loadedSig)
+	}
+	if _, err := core.NewVerifiedRuntimeInterface(loaded, *loadedSig, core.NewKeyRing(signer.PublicKey())); err != nil {
+		t.Fatalf("loaded artifact no longer verifies: %v", err)
+	}
+	if len(loaded.Graph.Nodes) != len(artifact.Graph.Nodes) || len(loaded.Graph.Edges) != len(artifact.Graph.Edges) {
+		t.Errorf("graph not rebuilt: %d nodes, %d edges", len(loaded.Graph.Nodes), len(loaded.Graph.Edges))
+	}
+
+	runtime := core.NewRuntimeInterface(loaded)
+	if result := runtime.IsAuthorized("analyst", "export", "/customers"); result["allowed"] != false {
+		t.Errorf("loaded artifact decided differently: %v", result)
+	}
+}
+
+func TestEmptyConditionsSurviveSignedRoundTrip(t *testing.T) {
+	claim := testClaim("c1", "permission", "analyst", "read", "/reports")
+	claim["conditions"] = map[string]interface{}{}
+	artifact := compileSources(t, testSource("s1", "regulatory", claim))
+	signer, err := core.GenerateEd25519Signer()
+	if err != nil {
+		t.Fatalf("GenerateEd25519Signer: %v", err)
+	}
+	sig, err := core.SignArtifact(signer, artifact)
+	if err != nil {
+		t.Fatalf("SignArtifact: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "artifact.json")
+	if err := core.WriteArtifactFile(path, artifact, &sig); err != nil {
+		t.Fatalf("WriteArtifactFile: %v", err)
+	}
+	loaded, loadedSig, err := core.ReadArtifactFile(path)
+	if err != nil {
+		t.Fatalf("ReadArtifactFile: %v", err)
+	}
+	if loaded.ID != artifact.ID {
+		t.Errorf("artifact ID changed: %s, want %s", loaded.ID, artifact.ID)
+	}
+	if err := core.VerifyArtifact(core.NewKeyRing(signer.PublicKey()), loaded, *loadedSig); err != nil {
+		t.Fatalf("loaded artifact no longer verifies: %v", err)
+	}
+
+	// Empty conditions hash like absent ones however the claim was built.
+	artifact.Claims[0].Conditions = map[string]interface{}{}
+	if err := core.VerifyArtifact(core.NewKeyRing(signer.PublicKey()), artifact, sig); err != nil {
+		t.Fatalf("empty conditions changed the digest: %v", err)
+	}
+}
+
+func TestMarshalArtifactIsDeterministic(t *testing.T) {
+	_, artifact, _ := signedFixture(t)
+	first, err := core.MarshalArtifact(artifact, nil)
+	if err != nil {
+		t.Fatalf("MarshalArtifact: %v", err)
+	}
+	loaded, sig, err := core.UnmarshalArtifact(first)
+	if err != nil {
+		t.Fatalf("UnmarshalArtifact: %v", err)
+	}
+	if sig != nil {
+		t.Errorf("expected no signature, got %+v", sig)
+	}
+	second, err := core.MarshalArtifact(loaded, nil)
+	if err != nil {
+		t.Fatalf("MarshalArtifact: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("re-encoding changed the file:\n%s\n%s", first, second)
+	}
+}
+
+func TestUnmarshalArtifactRejectsBadFiles(t *testing.T) {
+	_, artifact, _ := signedFixture(t)
+	data, err := core.MarshalArtifact(artifact, nil)
+	if err != nil {
+		t.Fatalf("MarshalArtifact: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	encode := func(mutate func(map[string]interface{})) []byte {
+		var copied map[string]interface{}
+		_ = json.Unmarshal(data, &copied)
+		mutate(copied)
+		out, _ := json.Marshal(copied)
+		return out
+	}
+
+	cases := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"future version", encode(func(d map[string]interface{}) { d["format_version"] = 99 }), core.ErrUnsupportedFormatVersion},
+		{"missing version", encode(func(d map[string]interface{}) { delete(d, "format_version") }), core.ErrInvalidArtifactFile},
+		{"unknown field", encode(func(d map[string]interface{}) { d["extra"] = true }), core.ErrInvalidArtifactFile},
+		{"dangling node", encode(func(d map[string]interface{}) {
+			graph := d["artifact"].(map[string]interface{})["graph"].(map[string]interface{})
+			graph["nodes"] = append(graph["nodes"].([]interface{}), "ghost")
+		}), core.ErrInvalidEdgeReference},
+		{"tampered id", encode(func(d map[string]interface{}) {
+			d["artifact"].(map[string]interface{})["id"] = "sha256:0000"
+		}), core.ErrInvalidArtifactFile},
+		{"tampered claim", encode(func(d map[string]interface{}) {
+			claims := d["artifact"].(map[string]interface{})["claims"].([]interface{})
+			claims[0].(map[string]interface{})["action"] = "delete"
+		}), core.ErrInvalidArtifactFile},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := core.UnmarshalArtifact(tc.data)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	// Structural validation runs on load.
+	invalid := encode(func(d map[string]interface{}) {
+		claims := d["artifact"].(map[string]interface{})["claims"].([]interface{})
+		claims[0].(map[string]interface{})["subject"] = ""
+	})
+	if _, _, err := core.UnmarshalArtifact(invalid); err == nil || !strings.Contains(err.Error(), "failed validation") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
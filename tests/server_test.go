@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"are/core"
+	"are/server"
+)
+
+func decisionServer(t *testing.T) (*server.Server, *httptest.Server) {
+	t.Helper()
+	audit := testClaim("audit_exports", "obligation", "analyst", "export", "/reports/*")
+	artifact := compileSources(t, withStrategy(testSource("policy", core.Organizational,
+		testClaim("export_reports", "permission", "analyst", "export", "/reports/*"),
+		testClaim("no_secret_export", "prohibition", "analyst", "export", "/reports/secret"),
+		audit,
+	), core.StrategyDenyOverrides))
+	handler := server.New(core.NewRuntimeInterface(artifact))
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return handler, ts
+}
+
+func postJSON(t *testing.T, url, body string, v interface{}) int {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("decoding response from %s: %v", url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerAnswersDecisionQueries(t *testing.T) {
+	_, ts := decisionServer(t)
+
+	var result core.AuthorizationResult
+	status := postJSON(t, ts.URL+"/v1/authorize", `{"subject":"analyst","action":"export","resource":"/reports/q3"}`, &result)
+	if status != http.StatusOK || !result.Allowed || result.AuthorityID != "export_reports" {
+		t.Fatalf("expected export_reports to allow, got %d %+v", status, result)
+	}
+
+	var batch server.BatchResponse
+	postJSON(t, ts.URL+"/v1/authorize/batch", `{"requests":[
+		{"subject":"analyst","action":"export","resource":"/reports/secret"},
+		{"subject":"intern","action":"export","resource":"/reports/q3"}]}`, &batch)
+	if len(batch.Results) != 2 || batch.Results[0].AuthorityID != "no_secret_export" || batch.Results[1].Allowed {
+		t.Fatalf("unexpected batch results: %+v", batch.Results)
+	}
+
+	var obligations server.ObligationsResponse
+	postJSON(t, ts.URL+"/v1/obligations", `{"subject":"analyst","action":"export","resource":"/reports/q3"}`, &obligations)
+	if len(obligations.Obligations) != 1 || obligations.Obligations[0].ID != "audit_exports" {
+		t.Fatalf("expected audit_exports, got %+v", obligations.Obligations)
+	}
+
+	var info core.AuthorityInfo
+	postJSON(t, ts.URL+"/v1/authority-info", `{"subject":"analyst","action":"export","resource":"/reports/secret"}`, &info)
+	if len(info.ApplicableClaims) != 3 || info.TotalClaims != 3 {
+		t.Fatalf("expected all three claims to apply, got %+v", info)
+	}
+}
+
+func TestServerRejectsMalformedRequests(t *testing.T) {
+	_, ts := decisionServer(t)
+
+	for name, body := range map[string]string{
+		"not JSON":        `subject=analyst`,
+		"missing field":   `{"subject":"analyst","action":"export"}`,
+		"unknown field":   `{"subject":"analyst","action":"export","resource":"/reports/q3","role":"admin"}`,
+		"trailing object": `{"subject":"analyst","action":"export","resource":"/reports/q3"}{}`,
+	} {
+		var errResp server.ErrorResponse
+		if status := postJSON(t, ts.URL+"/v1/authorize", body, &errResp); status != http.StatusBadRequest || errResp.Error == "" {
+			t.Errorf("%s: expected 400 with an error, got %d %+v", name, status, errResp)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/v1/authorize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerReadiness(t *testing.T) {
+	handler, ts := decisionServer(t)
+
+	for path, want := range map[string]int{"/healthz": http.StatusOK, "/readyz": http.StatusOK} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected %d, got %d", path, want, resp.StatusCode)
+		}
+	}
+
+	handler.SetReady(false)
+	resp, err := http.Get(ts.URL + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 once not ready, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"are/core"
+)
+
+func signedFixture(t *testing.T) (*core.Ed25519Signer, core.AuthorityArtifact, core.Signature) {
+	t.Helper()
+	signer, err := core.GenerateEd25519Signer()
+	if err != nil {
+		t.Fatalf("GenerateEd25519Signer: %v", err)
+	}
+	success, ok := core.NewAuthorityCompiler().ProcessSources(multiSourceFixture()).(core.CompilationSuccess)
+	if !ok {
+		t.Fatal("expected CompilationSuccess")
+	}
+	sig, err := core.SignArtifact(signer, success.Artifact)
+	if err != nil {
+		t.Fatalf("SignArtifact: %v", err)
+	}
+	return signer, success.Artifact, sig
+}
+
+func TestSignedArtifactVerifies(t *testing.T) {
+	signer, artifact, sig := signedFixture(t)
+	ring := core.NewKeyRing(signer.PublicKey())
+
+	if sig.KeyID != signer.KeyID() || sig.Algorithm != core.AlgorithmEd25519 {
+		t.Errorf("unexpected signature metadata: %+v", sig)
+	}
+	runtime, err := core.NewVerifiedRuntimeInterface(artifact, sig, ring)
+	if err != nil {
+		t.Fatalf("NewVerifiedRuntimeInterface: %v", err)
+	}
+	if runtime == nil {
+		t.Fatal("expected runtime interface")
+	}
+}
+
+func TestTamperedArtifactIsRejected(t *testing.T) {
+	signer, artifact, sig := signedFixture(t)
+	ring := core.NewKeyRing(signer.PublicKey())
+
+	artifact.Claims[0].Resource = "*"
+	_, err := core.NewVerifiedRuntimeInterface(artifact, sig, ring)
+	if !errors.Is(err, core.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	// Recomputing the digest does not help without the key.
+	forged := sig
+	forged.Digest = ""
+	if err := core.VerifyArtifact(ring, artifact, forged); !errors.Is(err, core.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestArtifactSignedByUntrustedKeyIsRejected(t *testing.T) {
+	_, artifact, sig := signedFixture(t)
+	other, err := core.GenerateEd25519Signer()
+	if err != nil {
+		t.Fatalf("GenerateEd25519Signer: %v", err)
+	}
+
+	err = core.VerifyArtifact(core.NewKeyRing(other.PublicKey()), artifact, sig)
+	if !errors.Is(err, core.ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestSwapEnforcesSignature(t *testing.T) {
+	signer, artifact, sig := signedFixture(t)
+	runtime, err := core.NewValidatedRuntimeInterface(artifact, core.WithSignature(sig, core.NewKeyRing(signer.PublicKey())))
+	if err != nil {
+		t.Fatalf("NewValidatedRuntimeInterface: %v", err)
+	}
+
+	tampered := artifact
+	tampered.Claims = append([]core.Claim{}, artifact.Claims...)
+	tampered.Claims[0].Resource = "*"
+	if _, err := runtime.Swap(tampered); !errors.Is(err, core.ErrInvalidSignature) {
+		t.Errorf("expected an unsigned swap to be refused, got %v", err)
+	}
+	if _, err := runtime.SwapVerified(tampered, sig); !errors.Is(err, core.ErrInvalidSignature) {
+		t.Errorf("expected a tampered swap to be refused, got %v", err)
+	}
+	if runtime.Generation() != 1 || runtime.GetArtifact().Claims[0].Resource == "*" {
+		t.Fatal("expected the signed artifact to stay in force")
+	}
+
+	resigned, err := core.SignArtifact(signer, tampered)
+	if err != nil {
+		t.Fatalf("SignArtifact: %v", err)
+	}
+	if generation, err := runtime.SwapVerified(tampered, resigned); err != nil || generation != 2 {
+		t.Errorf("expected a signed swap to be accepted, got generation %d, %v", generation, err)
+	}
+}
+
+func TestCanonicalArtifactIgnoresClaimOrder(t *testing.T) {
+	_, artifact, _ := signedFixture(t)
+	reordered := artifact
+	reordered.Claims = make([]core.Claim, len(artifact.Claims))
+	for i, claim := range artifact.Claims {
+		reordered.Claims[len(artifact.Claims)-1-i] = claim
+	}
+
+	want, err := core.CanonicalArtifact(artifact)
+	if err != nil {
+		t.Fatalf("CanonicalArtifact: %v", err)
+	}
+	got, err := core.CanonicalArtifact(reordered)
+	if err != nil {
+		t.Fatalf("CanonicalArtifact: %v", err)
+	}
+	if string(want) != string(got) {
+		t.Errorf("canonical encoding depends on claim order:\n%s\n%s", want, got)
+	}
+}
+
+func TestSignProofAndKeyFiles(t *testing.T) {
+	signer, artifact, _ := signedFixture(t)
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "signing.pem")
+	pubPath := filepath.Join(dir, "signing.pub.pem")
+	if err := signer.WritePrivateKey(privPath); err != nil {
+		t.Fatalf("WritePrivateKey: %v", err)
+	}
+	if err := signer.WritePublicKey(pubPath); err != nil {
+		t.Fatalf("WritePublicKey: %v", err)
+	}
+
+	loaded, err := core.LoadEd25519Signer(privPath)
+	if err != nil {
+		t.Fatalf("LoadEd25519Signer: %v", err)
+	}
+	if loaded.KeyID() != signer.KeyID() {
+		t.Errorf("loaded key ID %s, want %s", loaded.KeyID(), signer.KeyID())
+	}
+	ring, err := core.LoadKeyRing(pubPath)
+	if err != nil {
+		t.Fatalf("LoadKeyRing: %v", err)
+	}
+
+	proof := core.NewAuthorityCompiler().EmitProof(artifact)
+	sig, err := core.SignProof(loaded, proof)
+	if err != nil {
+		t.Fatalf("SignProof: %v", err)
+	}
+	if err := core.VerifyProof(ring, proof, sig); err != nil {
+		t.Fatalf("VerifyProof: %v", err)
+	}
+	if err := core.VerifyProof(ring, proof+" ", sig); !errors.Is(err, core.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for modified proof, got %v", err)
+	}
+
+	// A proof signature is not valid as an artifact signature.
+	if err := core.VerifyArtifact(ring, artifact, sig); !errors.Is(err, core.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for cross-domain signature, got %v", err)
+	}
+}
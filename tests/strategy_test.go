@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"are/core"
+)
+
+func withStrategy(source core.AuthoritySource, strategy string) core.AuthoritySource {
+	source.Metadata[core.StrategyMetadataKey] = strategy
+	return source
+}
+
+func TestSourceBoundPermitOverrides(t *testing.T) {
+	source := withStrategy(testSource("partner_contract", core.Contractual,
+		testClaim("share_reports", "permission", "partner", "read", "/reports/*"),
+		testClaim("no_report_access", "prohibition", "partner", "read", "/reports/*"),
+	), core.StrategyPermitOverrides)
+	artifact := compileSources(t, source)
+
+	if len(artifact.Strategies) != 1 || artifact.Strategies[0].SourceID != "partner_contract" {
+		t.Fatalf("expected the source binding to be recorded, got %+v", artifact.Strategies)
+	}
+	runtime := core.NewRuntimeInterface(artifact)
+	if result := runtime.IsAuthorized("partner", "read", "/reports/q3"); result["allowed"] != true {
+		t.Errorf("expected permit-overrides to let the permission win, got %v", result["reason"])
+	}
+}
+
+func TestNamespaceBoundDenyOverrides(t *testing.T) {
+	compiler := core.NewAuthorityCompiler()
+	if err := compiler.SetNamespaceStrategy("/finance/*", core.StrategyDenyOverrides); err != nil {
+		t.Fatalf("SetNamespaceStrategy: %v", err)
+	}
+	result := compiler.ProcessSources([]core.AuthoritySource{
+		testSource("constitution", core.Sovereign,
+			testClaim("ledger_read", "permission", "auditor", "read", "/finance/ledger")),
+		testSource("vendor_contract", core.Contractual,
+			testClaim("no_finance", "prohibition", "auditor", "read", "/finance/*"),
+			testClaim("no_hr", "prohibition", "auditor", "read", "/hr/*")),
+		testSource("charter", core.Sovereign,
+			testClaim("hr_read", "permission", "auditor", "read", "/hr/records")),
+	})
+	success, ok := result.(core.CompilationSuccess)
+	if !ok {
+		t.Fatalf("expected CompilationSuccess, got %+v", result)
+	}
+
+	runtime := core.NewRuntimeInterface(success.Artifact)
+	if result := runtime.IsAuthorized("auditor", "read", "/finance/ledger"); result["allowed"] != false {
+		t.Errorf("expected deny-overrides under /finance, got %v", result["reason"])
+	}
+	if result := runtime.IsAuthorized("auditor", "read", "/hr/records"); result["allowed"] != true {
+		t.Errorf("expected precedence outside /finance, got %v", result["reason"])
+	}
+}
+
+func TestFirstApplicableFollowsDeclarationOrder(t *testing.T) {
+	source := withStrategy(testSource("firewall_rules", core.Organizational,
+		testClaim("z_allow_ops", "permission", "ops", "ssh", "host-*"),
+		testClaim("a_block_prod", "prohibition", "ops", "ssh", "host-prod-*"),
+	), core.StrategyFirstApplicable)
+	runtime := core.NewRuntimeInterface(compileSources(t, source))
+
+	result := runtime.IsAuthorized("ops", "ssh", "host-prod-1")
+	if result["allowed"] != true {
+		t.Fatalf("expected the first declared rule to win, got %v", result["reason"])
+	}
+}
+
+func TestFirstApplicableSurvivesRoundTrip(t *testing.T) {
+	// Disjoint scopes keep both claims through compilation; without a request
+	// context both apply at runtime, and only their declaration order decides.
+	source := withStrategy(testSource("firewall_rules", core.Organizational,
+		withScope(testClaim("z_first", "permission", "ops", "ssh", "host-*"),
+			map[string]interface{}{"jurisdictions": []string{"EU"}}),
+		withScope(testClaim("a_second", "prohibition", "ops", "ssh", "host-prod-*"),
+			map[string]interface{}{"jurisdictions": []string{"US"}}),
+	), core.StrategyFirstApplicable)
+	artifact := compileSources(t, source)
+	if len(artifact.Claims) != 2 {
+		t.Fatalf("expected both claims to be kept, got %+v", artifact.Claims)
+	}
+
+	data, err := core.MarshalArtifact(artifact, nil)
+	if err != nil {
+		t.Fatalf("MarshalArtifact: %v", err)
+	}
+	loaded, _, err := core.UnmarshalArtifact(data)
+	if err != nil {
+		t.Fatalf("UnmarshalArtifact: %v", err)
+	}
+	for name, a := range map[string]core.AuthorityArtifact{"compiled": artifact, "loaded": loaded} {
+		result := core.NewRuntimeInterface(a).Authorize(core.AuthorizationRequest{Subject: "ops", Action: "ssh", Resource: "host-prod-1"})
+		if result.AuthorityID != "z_first" {
+			t.Errorf("%s: expected the first declared claim to decide, got %s", name, result.AuthorityID)
+		}
+	}
+
+	reordered := loaded
+	reordered.Claims = append([]core.Claim{}, loaded.Claims...)
+	for i := range reordered.Claims {
+		reordered.Claims[i].Position = len(reordered.Claims) - 1 - reordered.Claims[i].Position
+	}
+	original, err := core.ContentID(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swapped, _ := core.ContentID(reordered); swapped == original {
+		t.Error("expected declaration order to be part of the artifact content")
+	}
+}
+
+func TestOnlyOneApplicableRejectsOverlap(t *testing.T) {
+	source := withStrategy(testSource("exclusive_contract", core.Contractual,
+		testClaim("read_all", "permission", "partner", "read", "/data/*"),
+		testClaim("no_secret", "prohibition", "partner", "read", "/data/secret"),
+	), core.StrategyOnlyOneApplicable)
+
+	failure, ok := core.NewAuthorityCompiler().ProcessSources([]core.AuthoritySource{source}).(core.CompilationFailure)
+	if !ok {
+		t.Fatal("expected CompilationFailure")
+	}
+	if failure.FailureStage != "resolution" || !strings.Contains(failure.ViolatedInvariant, core.StrategyOnlyOneApplicable) {
+		t.Errorf("unexpected failure: %+v", failure)
+	}
+}
+
+func TestUnknownStrategyFailsClosed(t *testing.T) {
+	source := withStrategy(testSource("contract", core.Contractual,
+		testClaim("read_all", "permission", "partner", "read", "/data/*")), "coin-toss")
+
+	failure, ok := core.NewAuthorityCompiler().ProcessSources([]core.AuthoritySource{source}).(core.CompilationFailure)
+	if !ok || failure.FailureStage != "normalization" {
+		t.Fatalf("expected normalization failure, got %+v", failure)
+	}
+	if err := core.NewAuthorityCompiler().SetNamespaceStrategy("/data/*", "coin-toss"); err == nil {
+		t.Error("expected unknown namespace strategy to be rejected")
+	}
+}